@@ -0,0 +1,60 @@
+package sonarqube
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeGroupRead,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the group",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the group",
+			},
+			"members_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of users in the group",
+			},
+			"default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether new users are added to the group automatically",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("name").(string)
+
+	groups, err := getGroups(name, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeGroupRead: Failed to search groups: %+v", err)
+	}
+
+	// The search matches partial names, so look for the exact one
+	for _, group := range groups {
+		if group.Name == name {
+			d.SetId(group.ID)
+			d.Set("description", group.Description)
+			d.Set("members_count", group.MembersCount)
+			d.Set("default", group.IsDefault)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("dataSourceSonarqubeGroupRead: Group %q not found", name)
+}
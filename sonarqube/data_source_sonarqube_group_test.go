@@ -0,0 +1,41 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeGroupDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name        = "%[2]s"
+			description = "group description"
+		}
+
+		data "sonarqube_group" "%[1]s" {
+			name = sonarqube_group.%[1]s.name
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeGroupDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_group." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupDataSourceConfig(rnd, "testAccSonarqubeGroupDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "id", "sonarqube_group."+rnd, "id"),
+					resource.TestCheckResourceAttr(name, "description", "group description"),
+					resource.TestCheckResourceAttr(name, "members_count", "0"),
+					resource.TestCheckResourceAttr(name, "default", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,139 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetLanguages for unmarshalling response body of languages list
+type GetLanguages struct {
+	Languages []Language `json:"languages"`
+}
+
+// Language used in GetLanguages
+type Language struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeLanguages() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeLanguagesRead,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return languages whose key or name contains this string",
+			},
+			"languages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Languages supported by the server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeLanguagesRead(d *schema.ResourceData, m interface{}) error {
+	languages, err := sonarqubeLanguages(m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeLanguagesRead: %+v", err)
+	}
+
+	search := strings.ToLower(d.Get("search").(string))
+	flatLanguages := make([]interface{}, 0)
+	for _, language := range languages {
+		if strings.Contains(strings.ToLower(language.Key), search) || strings.Contains(strings.ToLower(language.Name), search) {
+			flatLanguages = append(flatLanguages, map[string]interface{}{
+				"key":  language.Key,
+				"name": language.Name,
+			})
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(search)))
+	d.Set("languages", flatLanguages)
+
+	return nil
+}
+
+// sonarqubeLanguages returns the languages supported by the server. They are fetched once per provider instance.
+func sonarqubeLanguages(m interface{}) ([]Language, error) {
+	conf := m.(*ProviderConfiguration)
+	conf.languagesLock.Lock()
+	defer conf.languagesLock.Unlock()
+
+	if conf.languages != nil {
+		return conf.languages, nil
+	}
+
+	sonarQubeURL := conf.sonarQubeURL
+	sonarQubeURL.Path = "api/languages/list"
+
+	resp, err := httpRequestHelper(
+		conf.httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"sonarqubeLanguages",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading Sonarqube languages: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	languagesResponse := GetLanguages{}
+	err = json.NewDecoder(resp.Body).Decode(&languagesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	conf.languages = languagesResponse.Languages
+	return conf.languages, nil
+}
+
+// validateLanguageDiff checks that the language attribute is supported by the server
+func validateLanguageDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// The language may only be known after other resources are applied
+	if !d.NewValueKnown("language") {
+		return nil
+	}
+
+	languages, err := sonarqubeLanguages(m)
+	if err != nil {
+		return err
+	}
+
+	language := d.Get("language").(string)
+	keys := make([]string, 0, len(languages))
+	for _, value := range languages {
+		if value.Key == language {
+			return nil
+		}
+		keys = append(keys, value.Key)
+	}
+
+	return fmt.Errorf("language %q is not supported by the server, expected one of %s", language, strings.Join(keys, ", "))
+}
@@ -0,0 +1,35 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeLanguagesDataSourceConfig(rnd string, search string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_languages" "%[1]s" {
+			search = "%[2]s"
+		}`, rnd, search)
+}
+
+func TestAccSonarqubeLanguagesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_languages." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeLanguagesDataSourceConfig(rnd, "kotlin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "languages.#", "1"),
+					resource.TestCheckResourceAttr(name, "languages.0.key", "kotlin"),
+					resource.TestCheckResourceAttr(name, "languages.0.name", "Kotlin"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package sonarqube
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeQualityProfileBackup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeQualityProfileBackupRead,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"quality_profile": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Quality profile name",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Quality profile language",
+			},
+			"backup": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Quality profile backup XML",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileBackupRead(d *schema.ResourceData, m interface{}) error {
+	backup, err := qualityProfileBackup(d.Get("quality_profile").(string), d.Get("language").(string), m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileBackupRead: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("language").(string), d.Get("quality_profile").(string)))
+	d.Set("backup", backup)
+
+	return nil
+}
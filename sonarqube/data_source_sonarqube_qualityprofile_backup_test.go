@@ -0,0 +1,41 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileBackupDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		data "sonarqube_qualityprofile_backup" "%[1]s" {
+			quality_profile = sonarqube_qualityprofile.%[1]s.name
+			language        = sonarqube_qualityprofile.%[1]s.language
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileBackupDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_backup." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileBackupDataSourceConfig(rnd, "testAccSonarqubeQualityProfileBackup"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "quality_profile", "testAccSonarqubeQualityProfileBackup"),
+					resource.TestMatchResourceAttr(name, "backup", regexp.MustCompile("<name>testAccSonarqubeQualityProfileBackup</name>")),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,174 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetQualityProfileChangelog for unmarshalling response body of quality profile changelog
+type GetQualityProfileChangelog struct {
+	Total  int64                          `json:"total"`
+	P      int64                          `json:"p"`
+	Ps     int64                          `json:"ps"`
+	Events []QualityProfileChangelogEvent `json:"events"`
+}
+
+// QualityProfileChangelogEvent used in GetQualityProfileChangelog
+type QualityProfileChangelogEvent struct {
+	Date        string            `json:"date"`
+	AuthorLogin string            `json:"authorLogin"`
+	AuthorName  string            `json:"authorName"`
+	Action      string            `json:"action"`
+	RuleKey     string            `json:"ruleKey"`
+	RuleName    string            `json:"ruleName"`
+	Params      map[string]string `json:"params"`
+}
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeQualityProfileChangelog() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeQualityProfileChangelogRead,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"quality_profile": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Quality profile name",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Quality profile language",
+			},
+			"since": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Start date (inclusive) of the changelog, e.g. 2017-10-19 or 2017-10-19T13:00:00+0200",
+			},
+			"to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "End date (exclusive) of the changelog, e.g. 2017-10-19 or 2017-10-19T13:00:00+0200",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Changes of the quality profile, most recent first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"author_login": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"author_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileChangelogRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/changelog"
+
+	rawQuery := url.Values{
+		"qualityProfile": []string{d.Get("quality_profile").(string)},
+		"language":       []string{d.Get("language").(string)},
+		"ps":             []string{"500"},
+	}
+	if since, ok := d.GetOk("since"); ok {
+		rawQuery.Add("since", since.(string))
+	}
+	if to, ok := d.GetOk("to"); ok {
+		rawQuery.Add("to", to.(string))
+	}
+
+	// Fetch every page of the changelog
+	events := make([]interface{}, 0)
+	for page := int64(1); ; page++ {
+		rawQuery.Set("p", strconv.FormatInt(page, 10))
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		changelog, err := getQualityProfileChangelogPage(sonarQubeURL, m)
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeQualityProfileChangelogRead: %+v", err)
+		}
+
+		for _, event := range changelog.Events {
+			events = append(events, map[string]interface{}{
+				"date":         event.Date,
+				"author_login": event.AuthorLogin,
+				"author_name":  event.AuthorName,
+				"action":       event.Action,
+				"rule_key":     event.RuleKey,
+				"rule_name":    event.RuleName,
+				"params":       event.Params,
+			})
+		}
+
+		if len(changelog.Events) == 0 || page*changelog.Ps >= changelog.Total {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", d.Get("language").(string), d.Get("quality_profile").(string), d.Get("since").(string), d.Get("to").(string)))
+	d.Set("events", events)
+
+	return nil
+}
+
+func getQualityProfileChangelogPage(sonarQubeURL url.URL, m interface{}) (GetQualityProfileChangelog, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getQualityProfileChangelogPage",
+	)
+	if err != nil {
+		return GetQualityProfileChangelog{}, fmt.Errorf("Error reading Sonarqube quality profile changelog: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	changelog := GetQualityProfileChangelog{}
+	err = json.NewDecoder(resp.Body).Decode(&changelog)
+	if err != nil {
+		return GetQualityProfileChangelog{}, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return changelog, nil
+}
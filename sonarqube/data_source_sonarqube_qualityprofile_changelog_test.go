@@ -0,0 +1,50 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileChangelogDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_bulk_activation" "%[1]s" {
+			quality_profile_key = sonarqube_qualityprofile.%[1]s.key
+			languages           = ["js"]
+			types               = ["VULNERABILITY"]
+		}
+
+		data "sonarqube_qualityprofile_changelog" "%[1]s" {
+			quality_profile = sonarqube_qualityprofile.%[1]s.name
+			language        = sonarqube_qualityprofile.%[1]s.language
+			since           = "2021-01-01"
+
+			depends_on = [sonarqube_qualityprofile_bulk_activation.%[1]s]
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileChangelogDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_changelog." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileChangelogDataSourceConfig(rnd, "testAccSonarqubeQualityProfileChangelog"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "events.#", "sonarqube_qualityprofile_bulk_activation."+rnd, "matched_rules"),
+					resource.TestCheckResourceAttr(name, "events.0.action", "ACTIVATED"),
+					resource.TestCheckResourceAttrSet(name, "events.0.rule_key"),
+				),
+			},
+		},
+	})
+}
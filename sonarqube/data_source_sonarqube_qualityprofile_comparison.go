@@ -0,0 +1,205 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CompareQualityProfiles for unmarshalling response body of quality profile compare
+type CompareQualityProfiles struct {
+	Left     QualityProfileCompareProfile    `json:"left"`
+	Right    QualityProfileCompareProfile    `json:"right"`
+	InLeft   []QualityProfileCompareRule     `json:"inLeft"`
+	InRight  []QualityProfileCompareRule     `json:"inRight"`
+	Same     []QualityProfileCompareRule     `json:"same"`
+	Modified []QualityProfileCompareModified `json:"modified"`
+}
+
+// QualityProfileCompareProfile used in CompareQualityProfiles
+type QualityProfileCompareProfile struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// QualityProfileCompareRule used in CompareQualityProfiles
+type QualityProfileCompareRule struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	PluginKey    string `json:"pluginKey"`
+	PluginName   string `json:"pluginName"`
+	LanguageKey  string `json:"languageKey"`
+	LanguageName string `json:"languageName"`
+	Severity     string `json:"severity"`
+}
+
+// QualityProfileCompareModified used in CompareQualityProfiles
+type QualityProfileCompareModified struct {
+	QualityProfileCompareRule
+	Left  QualityProfileCompareActivation `json:"left"`
+	Right QualityProfileCompareActivation `json:"right"`
+}
+
+// QualityProfileCompareActivation used in QualityProfileCompareModified
+type QualityProfileCompareActivation struct {
+	Severity string            `json:"severity"`
+	Params   map[string]string `json:"params"`
+}
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeQualityProfileComparison() *schema.Resource {
+	compareRule := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"severity": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Read: dataSourceSonarqubeQualityProfileComparisonRead,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"left_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Key of the left quality profile",
+			},
+			"right_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Key of the right quality profile",
+			},
+			"in_left": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules only active in the left quality profile",
+				Elem:        compareRule,
+			},
+			"in_right": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules only active in the right quality profile",
+				Elem:        compareRule,
+			},
+			"modified": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules active in both quality profiles with a different severity or parameters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"left_severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"right_severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"left_params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"right_params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"same_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of rules active with the same settings in both quality profiles",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileComparisonRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/compare"
+	sonarQubeURL.RawQuery = url.Values{
+		"leftKey":  []string{d.Get("left_key").(string)},
+		"rightKey": []string{d.Get("right_key").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeQualityProfileComparisonRead",
+	)
+	if err != nil {
+		return fmt.Errorf("Error comparing Sonarqube quality profiles: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	compareResponse := CompareQualityProfiles{}
+	err = json.NewDecoder(resp.Body).Decode(&compareResponse)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeQualityProfileComparisonRead: Failed to decode json into struct: %+v", err)
+	}
+
+	modified := make([]interface{}, 0, len(compareResponse.Modified))
+	for _, rule := range compareResponse.Modified {
+		modified = append(modified, map[string]interface{}{
+			"key":            rule.Key,
+			"name":           rule.Name,
+			"left_severity":  rule.Left.Severity,
+			"right_severity": rule.Right.Severity,
+			"left_params":    rule.Left.Params,
+			"right_params":   rule.Right.Params,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", compareResponse.Left.Key, compareResponse.Right.Key))
+	d.Set("in_left", flattenQualityProfileCompareRules(compareResponse.InLeft))
+	d.Set("in_right", flattenQualityProfileCompareRules(compareResponse.InRight))
+	d.Set("modified", modified)
+	d.Set("same_count", len(compareResponse.Same))
+
+	return nil
+}
+
+func flattenQualityProfileCompareRules(input []QualityProfileCompareRule) []interface{} {
+	flatRules := make([]interface{}, 0, len(input))
+	for _, rule := range input {
+		flatRules = append(flatRules, map[string]interface{}{
+			"key":      rule.Key,
+			"name":     rule.Name,
+			"severity": rule.Severity,
+		})
+	}
+
+	return flatRules
+}
@@ -0,0 +1,53 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileComparisonDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s_left" {
+			name     = "%[2]s-left"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s_right" {
+			name     = "%[2]s-right"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_bulk_activation" "%[1]s" {
+			quality_profile_key = sonarqube_qualityprofile.%[1]s_left.key
+			languages           = ["js"]
+			types               = ["BUG"]
+		}
+
+		data "sonarqube_qualityprofile_comparison" "%[1]s" {
+			left_key  = sonarqube_qualityprofile_bulk_activation.%[1]s.quality_profile_key
+			right_key = sonarqube_qualityprofile.%[1]s_right.key
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileComparisonDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_comparison." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileComparisonDataSourceConfig(rnd, "testAccSonarqubeQualityProfileComparison"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "in_left.#", "sonarqube_qualityprofile_bulk_activation."+rnd, "matched_rules"),
+					resource.TestCheckResourceAttr(name, "in_right.#", "0"),
+					resource.TestCheckResourceAttr(name, "modified.#", "0"),
+					resource.TestCheckResourceAttr(name, "same_count", "0"),
+				),
+			},
+		},
+	})
+}
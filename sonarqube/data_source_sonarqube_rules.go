@@ -0,0 +1,169 @@
+package sonarqube
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxRulesSearchResults is the maximum number of results api/rules/search returns for a query
+const maxRulesSearchResults = 10000
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeRules() *schema.Resource {
+	dataSourceSchema := map[string]*schema.Schema{
+		"quality_profile_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only return rules which are active (or inactive) in this quality profile",
+		},
+		"activation": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to return the rules active or inactive in quality_profile_key",
+		},
+		"is_template": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to return rule templates instead of rules",
+		},
+		"rules": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Rules matching the query",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"repository": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"language": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"severity": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"tags": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"system_tags": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"params": {
+						Type:     schema.TypeMap,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for key := range ruleQueryFilters {
+		dataSourceSchema[key] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Read: dataSourceSonarqubeRulesRead,
+
+		// Define the fields of this schema.
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourceSonarqubeRulesRead(d *schema.ResourceData, m interface{}) error {
+	rawQuery := expandRuleQuery(d)
+	rawQuery.Set("is_template", strconv.FormatBool(d.Get("is_template").(bool)))
+	if qualityProfileKey, ok := d.GetOk("quality_profile_key"); ok {
+		rawQuery.Add("qprofile", qualityProfileKey.(string))
+		rawQuery.Add("activation", strconv.FormatBool(d.Get("activation").(bool)))
+	}
+	rawQuery.Add("ps", "500")
+
+	// The query identifies the data source
+	id := rawQuery.Encode()
+
+	// Fetch every page of the search
+	rules := make([]interface{}, 0)
+	for page := int64(1); ; page++ {
+		rawQuery.Set("p", strconv.FormatInt(page, 10))
+
+		rulesResponse, err := searchRules(rawQuery, m)
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeRulesRead: %+v", err)
+		}
+		if rulesResponse.Total > maxRulesSearchResults {
+			return fmt.Errorf("dataSourceSonarqubeRulesRead: The query matches %d rules but Sonarqube returns at most %d, narrow down the query", rulesResponse.Total, maxRulesSearchResults)
+		}
+
+		for _, rule := range rulesResponse.Rules {
+			rules = append(rules, flattenRule(rule))
+		}
+
+		if len(rulesResponse.Rules) == 0 || page*rulesResponse.Ps >= rulesResponse.Total {
+			break
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(id)))
+	d.Set("rules", rules)
+
+	return nil
+}
+
+func flattenRule(rule Rule) map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, param := range rule.Params {
+		params[param.Key] = param.DefaultValue
+	}
+
+	return map[string]interface{}{
+		"key":         rule.Key,
+		"name":        rule.Name,
+		"repository":  rule.Repo,
+		"language":    rule.Lang,
+		"severity":    rule.Severity,
+		"type":        rule.Type,
+		"status":      rule.Status,
+		"tags":        rule.Tags,
+		"system_tags": rule.SysTags,
+		"params":      params,
+	}
+}
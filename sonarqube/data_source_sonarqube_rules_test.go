@@ -0,0 +1,58 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeRulesDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_bulk_activation" "%[1]s" {
+			quality_profile_key = sonarqube_qualityprofile.%[1]s.key
+			languages           = ["js"]
+			types               = ["BUG"]
+		}
+
+		data "sonarqube_rules" "%[1]s_bugs" {
+			languages = ["js"]
+			types     = ["BUG"]
+		}
+
+		data "sonarqube_rules" "%[1]s_active" {
+			quality_profile_key = sonarqube_qualityprofile_bulk_activation.%[1]s.quality_profile_key
+		}
+
+		data "sonarqube_rules" "%[1]s_templates" {
+			repositories = ["xml"]
+			is_template  = true
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeRulesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_rules." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeRulesDataSourceConfig(rnd, "testAccSonarqubeRules"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name+"_bugs", "rules.#", "sonarqube_qualityprofile_bulk_activation."+rnd, "matched_rules"),
+					resource.TestCheckResourceAttrPair(name+"_active", "rules.#", "sonarqube_qualityprofile_bulk_activation."+rnd, "matched_rules"),
+					resource.TestCheckResourceAttr(name+"_bugs", "rules.0.type", "BUG"),
+					resource.TestCheckResourceAttr(name+"_bugs", "rules.0.language", "js"),
+					resource.TestCheckTypeSetElemNestedAttrs(name+"_templates", "rules.*", map[string]string{"key": "xml:XPathCheck"}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,103 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// userDataSourceSchema returns the computed user attributes shared by the user data sources
+func userDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"login_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"email": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"is_local": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"external_identity": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"external_provider": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_connection_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"groups": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeUser() *schema.Resource {
+	dataSourceSchema := userDataSourceSchema()
+	dataSourceSchema["login_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Login of the user",
+	}
+
+	return &schema.Resource{
+		Read: dataSourceSonarqubeUserRead,
+
+		// Define the fields of this schema.
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
+	login := d.Get("login_name").(string)
+
+	users, err := getUsers(url.Values{
+		"q": []string{login},
+	}, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeUserRead: Failed to search users: %+v", err)
+	}
+
+	// The search matches partial logins, names and emails, so look for the exact login
+	for _, user := range users {
+		if user.Login == login {
+			d.SetId(user.Login)
+			for key, value := range flattenUser(user) {
+				d.Set(key, value)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("dataSourceSonarqubeUserRead: User %q not found", login)
+}
+
+func flattenUser(user User) map[string]interface{} {
+	return map[string]interface{}{
+		"login_name":           user.Login,
+		"name":                 user.Name,
+		"email":                user.Email,
+		"is_local":             user.IsLocal,
+		"external_identity":    user.ExternalIdentity,
+		"external_provider":    user.ExternalProvider,
+		"groups":               user.Groups,
+		"last_connection_date": user.LastConnectionDate,
+	}
+}
@@ -0,0 +1,44 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeUserDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			email      = "%[2]s@example.com"
+			password   = "secret-sauce37!"
+		}
+
+		data "sonarqube_user" "%[1]s" {
+			login_name = sonarqube_user.%[1]s.login_name
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeUserDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserDataSourceConfig(rnd, "testAccSonarqubeUserDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeUserDataSource"),
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeUserDataSource"),
+					resource.TestCheckResourceAttr(name, "email", "testAccSonarqubeUserDataSource@example.com"),
+					resource.TestCheckResourceAttr(name, "is_local", "true"),
+					resource.TestCheckTypeSetElemAttr(name, "groups.*", "sonar-users"),
+				),
+			},
+		},
+	})
+}
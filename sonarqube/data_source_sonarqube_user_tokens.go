@@ -0,0 +1,157 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeUserTokens() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeUserTokensRead,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"login_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Login of the user whose tokens are listed, defaults to the authenticated user",
+			},
+			"last_connection_older_than_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "Only return tokens which weren't used for this number of days, including tokens which were never used",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"created_older_than_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "Only return tokens which were created more than this number of days ago",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"tokens": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Tokens matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expires_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_connection_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeUserTokensRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/user_tokens/search"
+	if login, ok := d.GetOk("login_name"); ok {
+		sonarQubeURL.RawQuery = url.Values{
+			"login": []string{login.(string)},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeUserTokensRead",
+	)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeUserTokensRead: Failed to read user tokens: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getTokensResponse := GetTokens{}
+	err = json.NewDecoder(resp.Body).Decode(&getTokensResponse)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeUserTokensRead: Failed to decode json into struct: %+v", err)
+	}
+
+	lastConnectionDays := d.Get("last_connection_older_than_days").(int)
+	createdDays := d.Get("created_older_than_days").(int)
+
+	tokens := make([]interface{}, 0, len(getTokensResponse.Tokens))
+	for _, token := range getTokensResponse.Tokens {
+		if lastConnectionDays > 0 {
+			// Tokens which were never used are unused since their creation
+			lastUsed := token.LastConnectionDate
+			if lastUsed == "" {
+				lastUsed = token.CreatedAt
+			}
+			older, err := olderThanDays(lastUsed, lastConnectionDays)
+			if err != nil {
+				return fmt.Errorf("dataSourceSonarqubeUserTokensRead: %+v", err)
+			}
+			if !older {
+				continue
+			}
+		}
+		if createdDays > 0 {
+			if token.CreatedAt == "" {
+				continue
+			}
+			older, err := olderThanDays(token.CreatedAt, createdDays)
+			if err != nil {
+				return fmt.Errorf("dataSourceSonarqubeUserTokensRead: %+v", err)
+			}
+			if !older {
+				continue
+			}
+		}
+
+		tokenType := token.Type
+		if tokenType == "" {
+			// Servers before 9.5 only have user tokens
+			tokenType = "USER_TOKEN"
+		}
+
+		tokens = append(tokens, map[string]interface{}{
+			"name":                 token.Name,
+			"type":                 tokenType,
+			"project_key":          token.Project.Key,
+			"created_at":           token.CreatedAt,
+			"expires_at":           token.ExpirationDate,
+			"last_connection_date": token.LastConnectionDate,
+		})
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s/%d/%d", getTokensResponse.Login, lastConnectionDays, createdDays))))
+	d.Set("login_name", getTokensResponse.Login)
+	d.Set("tokens", tokens)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeUserTokensDataSourceConfig(rnd string, name string, filters string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_user_token" "%[1]s" {
+			login_name = sonarqube_user.%[1]s.login_name
+			name       = "%[2]s"
+		}
+
+		data "sonarqube_user_tokens" "%[1]s" {
+			login_name = sonarqube_user_token.%[1]s.login_name
+			%[3]s
+		}`, rnd, name, filters)
+}
+
+func TestAccSonarqubeUserTokensDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_user_tokens." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserTokensDataSourceConfig(rnd, "testAccSonarqubeUserTokensDataSource", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tokens.#", "1"),
+					resource.TestCheckResourceAttr(name, "tokens.0.name", "testAccSonarqubeUserTokensDataSource"),
+					resource.TestCheckResourceAttr(name, "tokens.0.type", "USER_TOKEN"),
+					resource.TestCheckResourceAttr(name, "tokens.0.last_connection_date", ""),
+				),
+			},
+			{
+				// A new token which was never used is not stale yet
+				Config: testAccSonarqubeUserTokensDataSourceConfig(rnd, "testAccSonarqubeUserTokensDataSource", "last_connection_older_than_days = 30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tokens.#", "0"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserTokensDataSourceConfig(rnd, "testAccSonarqubeUserTokensDataSource", "created_older_than_days = 30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tokens.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the data source represented by this file.
+func dataSourceSonarqubeUsers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSonarqubeUsersRead,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users whose login, name or email contains this string",
+			},
+			"last_connection_older_than_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "Only return users who didn't log in for this number of days, including users who never logged in",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Active users matching the search",
+				Elem: &schema.Resource{
+					Schema: userDataSourceSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeUsersRead(d *schema.ResourceData, m interface{}) error {
+	search := d.Get("search").(string)
+
+	rawQuery := url.Values{}
+	if search != "" {
+		rawQuery.Add("q", search)
+	}
+
+	users, err := getUsers(rawQuery, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeUsersRead: Failed to search users: %+v", err)
+	}
+
+	lastConnectionDays := d.Get("last_connection_older_than_days").(int)
+
+	flattenedUsers := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		if lastConnectionDays > 0 {
+			older, err := olderThanDays(user.LastConnectionDate, lastConnectionDays)
+			if err != nil {
+				return fmt.Errorf("dataSourceSonarqubeUsersRead: %+v", err)
+			}
+			if !older {
+				continue
+			}
+		}
+		flattenedUsers = append(flattenedUsers, flattenUser(user))
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s/%d", search, lastConnectionDays))))
+	d.Set("users", flattenedUsers)
+
+	return nil
+}
+
+// olderThanDays returns whether a Sonarqube date time is more than the given number of days ago.
+// An empty date time, e.g. of a user who never logged in, is older than any number of days.
+func olderThanDays(dateTime string, days int) (bool, error) {
+	if dateTime == "" {
+		return true, nil
+	}
+
+	parsed, err := time.Parse(sonarqubeDateTimeFormat, dateTime)
+	if err != nil {
+		return false, fmt.Errorf("Failed to parse date time %s: %+v", dateTime, err)
+	}
+
+	return parsed.Before(time.Now().AddDate(0, 0, -days)), nil
+}
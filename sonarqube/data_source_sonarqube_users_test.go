@@ -0,0 +1,75 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccSonarqubeUsersDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+
+		data "sonarqube_users" "%[1]s" {
+			search = sonarqube_user.%[1]s.login_name
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeUsersDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_users." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUsersDataSourceConfig(rnd, "testAccSonarqubeUsersDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "users.#", "1"),
+					resource.TestCheckResourceAttr(name, "users.0.login_name", "testAccSonarqubeUsersDataSource"),
+					resource.TestCheckResourceAttr(name, "users.0.name", "testAccSonarqubeUsersDataSource"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUsersDataSourceLastConnectionConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+
+		data "sonarqube_users" "%[1]s" {
+			search                          = sonarqube_user.%[1]s.login_name
+			last_connection_older_than_days = 90
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeUsersDataSourceLastConnection(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_users." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// A user who never logged in matches any last connection filter, Sonarqube doesn't return when users were created
+				Config: testAccSonarqubeUsersDataSourceLastConnectionConfig(rnd, "testAccSonarqubeUsersDataSourceLastConnection"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "users.#", "1"),
+					resource.TestCheckResourceAttr(name, "users.0.last_connection_date", ""),
+				),
+			},
+		},
+	})
+}
@@ -1,8 +1,10 @@
 package sonarqube
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -27,11 +29,38 @@ type Paging struct {
 
 // helper function to make api request to sonarqube
 func httpRequestHelper(client *retryablehttp.Client, method string, sonarqubeURL string, expectedResponseCode int, errormsg string) (http.Response, error) {
+	return httpRequestBodyHelper(client, method, sonarqubeURL, "", http.NoBody, expectedResponseCode, errormsg)
+}
+
+// helper function to make api request with a file upload to sonarqube
+func httpMultipartRequestHelper(client *retryablehttp.Client, method string, sonarqubeURL string, fieldName string, fileName string, content []byte, expectedResponseCode int, errormsg string) (http.Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(fieldName, fileName)
+	if err != nil {
+		return http.Response{}, fmt.Errorf("Failed to prepare multipart request: %v", err)
+	}
+	if _, err = part.Write(content); err != nil {
+		return http.Response{}, fmt.Errorf("Failed to prepare multipart request: %v", err)
+	}
+	if err = writer.Close(); err != nil {
+		return http.Response{}, fmt.Errorf("Failed to prepare multipart request: %v", err)
+	}
+
+	// Pass the body as bytes so the request can be retried
+	return httpRequestBodyHelper(client, method, sonarqubeURL, writer.FormDataContentType(), body.Bytes(), expectedResponseCode, errormsg)
+}
+
+// helper function to make api request with a body to sonarqube
+func httpRequestBodyHelper(client *retryablehttp.Client, method string, sonarqubeURL string, contentType string, body interface{}, expectedResponseCode int, errormsg string) (http.Response, error) {
 	// Prepare request
-	req, err := retryablehttp.NewRequest(method, sonarqubeURL, http.NoBody)
+	req, err := retryablehttp.NewRequest(method, sonarqubeURL, body)
 	if err != nil {
 		return http.Response{}, fmt.Errorf("Failed to prepare http request: %v. Request: %v", err, req)
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	// Execute request
 	resp, err := client.Do(req)
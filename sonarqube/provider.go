@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/go-version"
@@ -37,18 +38,36 @@ func Provider() *schema.Provider {
 		// Add the resources supported by this provider to this map.
 		ResourcesMap: map[string]*schema.Resource{
 			"sonarqube_group":                              resourceSonarqubeGroup(),
+			"sonarqube_group_member":                       resourceSonarqubeGroupMember(),
 			"sonarqube_permission_template":                resourceSonarqubePermissionTemplate(),
 			"sonarqube_permissions":                        resourceSonarqubePermissions(),
 			"sonarqube_plugin":                             resourceSonarqubePlugin(),
 			"sonarqube_project":                            resourceSonarqubeProject(),
 			"sonarqube_qualityprofile":                     resourceSonarqubeQualityProfile(),
+			"sonarqube_qualityprofile_bulk_activation":     resourceSonarqubeQualityProfileBulkActivation(),
+			"sonarqube_qualityprofile_permission":          resourceSonarqubeQualityProfilePermission(),
 			"sonarqube_qualityprofile_project_association": resourceSonarqubeQualityProfileProjectAssociation(),
+			"sonarqube_qualityprofile_restore":             resourceSonarqubeQualityProfileRestore(),
 			"sonarqube_qualitygate":                        resourceSonarqubeQualityGate(),
 			"sonarqube_qualitygate_condition":              resourceSonarqubeQualityGateCondition(),
 			"sonarqube_qualitygate_project_association":    resourceSonarqubeQualityGateProjectAssociation(),
+			"sonarqube_rule":                               resourceSonarqubeRule(),
+			"sonarqube_rule_metadata":                      resourceSonarqubeRuleMetadata(),
 			"sonarqube_user":                               resourceSonarqubeUser(),
 			"sonarqube_user_token":                         resourceSonarqubeUserToken(),
 		},
+		// Add the data sources supported by this provider to this map.
+		DataSourcesMap: map[string]*schema.Resource{
+			"sonarqube_group":                     dataSourceSonarqubeGroup(),
+			"sonarqube_languages":                 dataSourceSonarqubeLanguages(),
+			"sonarqube_qualityprofile_backup":     dataSourceSonarqubeQualityProfileBackup(),
+			"sonarqube_qualityprofile_changelog":  dataSourceSonarqubeQualityProfileChangelog(),
+			"sonarqube_qualityprofile_comparison": dataSourceSonarqubeQualityProfileComparison(),
+			"sonarqube_rules":                     dataSourceSonarqubeRules(),
+			"sonarqube_user":                      dataSourceSonarqubeUser(),
+			"sonarqube_user_tokens":               dataSourceSonarqubeUserTokens(),
+			"sonarqube_users":                     dataSourceSonarqubeUsers(),
+		},
 		ConfigureFunc: configureProvider,
 	}
 	return sonarqubeProvider
@@ -56,8 +75,13 @@ func Provider() *schema.Provider {
 
 //ProviderConfiguration contains the sonarqube providers configuration
 type ProviderConfiguration struct {
-	httpClient   *retryablehttp.Client
-	sonarQubeURL url.URL
+	httpClient       *retryablehttp.Client
+	sonarQubeURL     url.URL
+	sonarQubeVersion *version.Version
+
+	// languages supported by the server, see sonarqubeLanguages
+	languagesLock sync.Mutex
+	languages     []Language
 }
 
 func configureProvider(d *schema.ResourceData) (interface{}, error) {
@@ -76,40 +100,41 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	// Check that the sonarqube api is available and a supported version
-	err = sonarqubeHealth(client, sonarQubeURL)
+	installedVersion, err := sonarqubeHealth(client, sonarQubeURL)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ProviderConfiguration{
-		httpClient:   client,
-		sonarQubeURL: sonarQubeURL,
+		httpClient:       client,
+		sonarQubeURL:     sonarQubeURL,
+		sonarQubeVersion: installedVersion,
 	}, nil
 }
 
-func sonarqubeHealth(client *retryablehttp.Client, sonarqube url.URL) error {
+func sonarqubeHealth(client *retryablehttp.Client, sonarqube url.URL) (*version.Version, error) {
 	// Make request to sonarqube version endpoint
 	sonarqube.Path = "api/server/version"
 	req, err := retryablehttp.NewRequest("GET", sonarqube.String(), http.NoBody)
 	if err != nil {
-		return fmt.Errorf("Unable to construct sonarqube version request: %+v", err)
+		return nil, fmt.Errorf("Unable to construct sonarqube version request: %+v", err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("Unable to reach sonarqube: %+v", err)
+		return nil, fmt.Errorf("Unable to reach sonarqube: %+v", err)
 	}
 	defer resp.Body.Close()
 
 	// Check response code
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Sonarqube version api did not return a 200: %+v", err)
+		return nil, fmt.Errorf("Sonarqube version api did not return a 200: %+v", err)
 	}
 
 	// Read in the response
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("Failed to parse response body on GET sonarqube version api: %+v", err)
+		return nil, fmt.Errorf("Failed to parse response body on GET sonarqube version api: %+v", err)
 	}
 
 	// Convert response to a int.
@@ -118,12 +143,12 @@ func sonarqubeHealth(client *retryablehttp.Client, sonarqube url.URL) error {
 	allowedVersion, _ := version.NewVersion("8.4")
 
 	if err != nil {
-		return fmt.Errorf("Failed to convert sonarqube version to a version: %+v", err)
+		return nil, fmt.Errorf("Failed to convert sonarqube version to a version: %+v", err)
 	}
 
 	if installedVersion.LessThan(allowedVersion) {
-		return fmt.Errorf("Unsupported version of sonarqube. Minimum supported version is %+v. Running version is %+v", allowedVersion, installedVersion)
+		return nil, fmt.Errorf("Unsupported version of sonarqube. Minimum supported version is %+v. Running version is %+v", allowedVersion, installedVersion)
 	}
 
-	return nil
+	return installedVersion, nil
 }
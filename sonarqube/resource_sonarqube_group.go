@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -47,12 +48,23 @@ func resourceSonarqubeGroup() *schema.Resource {
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"members": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"manage_members": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -85,38 +97,27 @@ func resourceSonarqubeGroupCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(groupResponse.Group.ID)
+
+	if groupMembersManaged(d) {
+		err = groupMembersUpdate(d.Get("name").(string), &schema.Set{F: schema.HashString}, d.Get("members").(*schema.Set), m)
+		if err != nil {
+			return fmt.Errorf("Error creating Sonarqube group: %+v", err)
+		}
+	}
+
 	return resourceSonarqubeGroupRead(d, m)
 }
 
 func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = "api/user_groups/search"
-	sonarQubeURL.RawQuery = url.Values{
-		"q": []string{d.Get("name").(string)},
-	}.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"GET",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceSonarqubeGroupRead",
-	)
+	// Search all groups, as the group might have been renamed outside of terraform
+	groups, err := getGroups("", m)
 	if err != nil {
 		return fmt.Errorf("Error reading Sonarqube group: %+v", err)
 	}
-	defer resp.Body.Close()
-
-	// Decode response into struct
-	groupReadResponse := GetGroup{}
-	err = json.NewDecoder(resp.Body).Decode(&groupReadResponse)
-	if err != nil {
-		return fmt.Errorf("resourceSonarqubeGroupRead: Failed to decode json into struct: %+v", err)
-	}
 
 	// Loop over all groups to see if the group we need exists.
 	readSuccess := false
-	for _, value := range groupReadResponse.Groups {
+	for _, value := range groups {
 		if d.Id() == value.ID {
 			// If it does, set the values of that group
 			d.SetId(value.ID)
@@ -129,6 +130,16 @@ func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
 	if !readSuccess {
 		// Group not found
 		d.SetId("")
+		return nil
+	}
+
+	// Reconcile the complete membership when the members are managed
+	if groupMembersManaged(d) {
+		members, err := getGroupMemberLogins(d.Get("name").(string), m)
+		if err != nil {
+			return fmt.Errorf("Error reading Sonarqube group members: %+v", err)
+		}
+		d.Set("members", members)
 	}
 
 	return nil
@@ -142,6 +153,10 @@ func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
 		"id": []string{d.Id()},
 	}
 
+	if d.HasChange("name") {
+		rawQuery.Add("name", d.Get("name").(string))
+	}
+
 	if _, ok := d.GetOk("description"); ok {
 		rawQuery.Add("description", d.Get("description").(string))
 	} else {
@@ -162,6 +177,18 @@ func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 	defer resp.Body.Close()
 
+	// The members in the state are unknown when they were not managed before, so compare with the current members
+	if groupMembersManaged(d) && d.HasChanges("members", "manage_members") {
+		currentMembers, err := getGroupMemberLogins(d.Get("name").(string), m)
+		if err != nil {
+			return fmt.Errorf("Error updating Sonarqube group: %+v", err)
+		}
+		err = groupMembersUpdate(d.Get("name").(string), currentMembers, d.Get("members").(*schema.Set), m)
+		if err != nil {
+			return fmt.Errorf("Error updating Sonarqube group: %+v", err)
+		}
+	}
+
 	return resourceSonarqubeGroupRead(d, m)
 }
 
@@ -188,8 +215,102 @@ func resourceSonarqubeGroupDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("manage_members", false)
 	if err := resourceSonarqubeGroupRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// groupMembersManaged returns whether the complete membership of the group is managed.
+// Without manage_members an empty set leaves the members unmanaged.
+func groupMembersManaged(d *schema.ResourceData) bool {
+	return d.Get("manage_members").(bool) || d.Get("members").(*schema.Set).Len() > 0
+}
+
+// getGroupMemberLogins returns the logins of all members of a group
+func getGroupMemberLogins(groupName string, m interface{}) (*schema.Set, error) {
+	members, err := getGroupMembers(groupName, "", m)
+	if err != nil {
+		return nil, err
+	}
+
+	logins := &schema.Set{F: schema.HashString}
+	for _, member := range members {
+		logins.Add(member.Login)
+	}
+	return logins, nil
+}
+
+// groupMembersUpdate adds and removes users so the group members change from oldMembers to newMembers
+func groupMembersUpdate(groupName string, oldMembers *schema.Set, newMembers *schema.Set, m interface{}) error {
+	for _, login := range expandStringSet(newMembers.Difference(oldMembers)) {
+		if err := groupMembershipChange("api/user_groups/add_user", groupName, login, m); err != nil {
+			return fmt.Errorf("Failed to add user %s to group %s: %+v", login, groupName, err)
+		}
+	}
+
+	for _, login := range expandStringSet(oldMembers.Difference(newMembers)) {
+		if err := groupMembershipChange("api/user_groups/remove_user", groupName, login, m); err != nil {
+			return fmt.Errorf("Failed to remove user %s from group %s: %+v", login, groupName, err)
+		}
+	}
+
+	return nil
+}
+
+// getGroups returns all groups, optionally filtered by a search query
+func getGroups(query string, m interface{}) ([]Group, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/user_groups/search"
+
+	rawQuery := url.Values{
+		"ps": []string{"500"},
+	}
+	if query != "" {
+		rawQuery.Add("q", query)
+	}
+
+	groups := make([]Group, 0)
+	for page := int64(1); ; page++ {
+		rawQuery.Set("p", strconv.FormatInt(page, 10))
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		groupsResponse, err := getGroupsPage(sonarQubeURL, m)
+		if err != nil {
+			return nil, fmt.Errorf("getGroups: %+v", err)
+		}
+
+		groups = append(groups, groupsResponse.Groups...)
+
+		if len(groupsResponse.Groups) == 0 || page*groupsResponse.Paging.PageSize >= groupsResponse.Paging.Total {
+			break
+		}
+	}
+
+	return groups, nil
+}
+
+// getGroupsPage returns a page of the groups matching the search parameters
+func getGroupsPage(sonarQubeURL url.URL, m interface{}) (GetGroup, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getGroupsPage",
+	)
+	if err != nil {
+		return GetGroup{}, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	groupsResponse := GetGroup{}
+	err = json.NewDecoder(resp.Body).Decode(&groupsResponse)
+	if err != nil {
+		return GetGroup{}, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return groupsResponse, nil
+}
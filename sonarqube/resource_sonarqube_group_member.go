@@ -0,0 +1,207 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetGroupMembers for unmarshalling response body of group users
+type GetGroupMembers struct {
+	P     int64         `json:"p"`
+	Ps    int64         `json:"ps"`
+	Total int64         `json:"total"`
+	Users []GroupMember `json:"users"`
+}
+
+// GroupMember used in GetGroupMembers
+type GroupMember struct {
+	Login    string `json:"login"`
+	Name     string `json:"name"`
+	Selected bool   `json:"selected"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGroupMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeGroupMemberCreate,
+		Read:   resourceSonarqubeGroupMemberRead,
+		Delete: resourceSonarqubeGroupMemberDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGroupMemberImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the group",
+			},
+			"login_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Login of the user",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGroupMemberCreate(d *schema.ResourceData, m interface{}) error {
+	err := groupMembershipChange("api/user_groups/add_user", d.Get("name").(string), d.Get("login_name").(string), m)
+	if err != nil {
+		return fmt.Errorf("Error creating Sonarqube group member: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("name").(string), d.Get("login_name").(string)))
+	return resourceSonarqubeGroupMemberRead(d, m)
+}
+
+func resourceSonarqubeGroupMemberRead(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("name").(string)
+	login := d.Get("login_name").(string)
+
+	members, err := getGroupMembers(groupName, login, m)
+	if err != nil {
+		return fmt.Errorf("Error reading Sonarqube group member: %+v", err)
+	}
+
+	// Loop over all members to see if the user is still in the group
+	readSuccess := false
+	for _, value := range members {
+		if value.Login == login {
+			readSuccess = true
+		}
+	}
+
+	if !readSuccess {
+		// Membership not found
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceSonarqubeGroupMemberDelete(d *schema.ResourceData, m interface{}) error {
+	err := groupMembershipChange("api/user_groups/remove_user", d.Get("name").(string), d.Get("login_name").(string), m)
+	if err != nil {
+		return fmt.Errorf("Error deleting Sonarqube group member: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeGroupMemberImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Id is composed of group name and login, group names may contain slashes
+	id := d.Id()
+	separator := strings.LastIndex(id, "/")
+	if separator < 1 || separator == len(id)-1 {
+		return nil, fmt.Errorf("resourceSonarqubeGroupMemberImport: Invalid id %q, expected group/login", id)
+	}
+	d.Set("name", id[:separator])
+	d.Set("login_name", id[separator+1:])
+
+	if err := resourceSonarqubeGroupMemberRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeGroupMemberImport: Group member %q not found", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// groupMembershipChange adds or removes a user to or from a group, depending on the api path
+func groupMembershipChange(path string, groupName string, login string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = path
+	sonarQubeURL.RawQuery = url.Values{
+		"name":  []string{groupName},
+		"login": []string{login},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"groupMembershipChange",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// getGroupMembers returns all members of a group, optionally filtered by a search query
+func getGroupMembers(groupName string, query string, m interface{}) ([]GroupMember, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/user_groups/users"
+
+	rawQuery := url.Values{
+		"name":     []string{groupName},
+		"selected": []string{"selected"},
+		"ps":       []string{"500"},
+	}
+	if query != "" {
+		rawQuery.Add("q", query)
+	}
+
+	members := make([]GroupMember, 0)
+	for page := int64(1); ; page++ {
+		rawQuery.Set("p", strconv.FormatInt(page, 10))
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		membersResponse, err := getGroupMembersPage(sonarQubeURL, m)
+		if err != nil {
+			return nil, fmt.Errorf("getGroupMembers: %+v", err)
+		}
+		if membersResponse == nil {
+			// A group which doesn't exist has no members
+			return members, nil
+		}
+
+		members = append(members, membersResponse.Users...)
+
+		if len(membersResponse.Users) == 0 || page*membersResponse.Ps >= membersResponse.Total {
+			break
+		}
+	}
+
+	return members, nil
+}
+
+// getGroupMembersPage returns a page of the members of a group, or nil if the group doesn't exist
+func getGroupMembersPage(sonarQubeURL url.URL, m interface{}) (*GetGroupMembers, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getGroupMembersPage",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	membersResponse := GetGroupMembers{}
+	err = json.NewDecoder(resp.Body).Decode(&membersResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return &membersResponse, nil
+}
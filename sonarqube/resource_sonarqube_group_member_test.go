@@ -0,0 +1,62 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_group_member", &resource.Sweeper{
+		Name: "sonarqube_group_member",
+		F:    testSweepSonarqubeGroupMemberSweeper,
+	})
+}
+
+func testSweepSonarqubeGroupMemberSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeGroupMemberBasicConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_group_member" "%[1]s" {
+			name       = sonarqube_group.%[1]s.name
+			login_name = sonarqube_user.%[1]s.login_name
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeGroupMemberBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group_member." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupMemberBasicConfig(rnd, "testAccSonarqubeGroupMember"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeGroupMember"),
+					resource.TestCheckResourceAttr(name, "login_name", "testAccSonarqubeGroupMember"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "testAccSonarqubeGroupMember/testAccSonarqubeGroupMember",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
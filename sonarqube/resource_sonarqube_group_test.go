@@ -50,6 +50,13 @@ func TestAccSonarqubeGroupBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "description", "group description 2"),
 				),
 			},
+			{
+				Config: testAccSonarqubeGroupBasicConfig(rnd, "testAccSonarqubeGroupRenamed", "group description 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeGroupRenamed"),
+					resource.TestCheckResourceAttr(name, "description", "group description 2"),
+				),
+			},
 			{
 				ResourceName:      name,
 				ImportState:       true,
@@ -62,3 +69,63 @@ func TestAccSonarqubeGroupBasic(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeGroupMembersConfig(rnd string, name string, members string, manageMembers bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s_a" {
+		  login_name = "%[2]s-a"
+		  name       = "%[2]s-a"
+		  password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_user" "%[1]s_b" {
+		  login_name = "%[2]s-b"
+		  name       = "%[2]s-b"
+		  password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+		  name           = "%[2]s"
+		  members        = %[3]s
+		  manage_members = %[4]t
+		}
+		`, rnd, name, members, manageMembers)
+}
+
+func TestAccSonarqubeGroupMembers(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", fmt.Sprintf("[sonarqube_user.%s_a.login_name]", rnd), false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "members.*", "testAccSonarqubeGroupMembers-a"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", fmt.Sprintf("[sonarqube_user.%s_b.login_name]", rnd), false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "members.*", "testAccSonarqubeGroupMembers-b"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", "[]", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "0"),
+					resource.TestCheckResourceAttr(name, "manage_members", "true"),
+				),
+			},
+			{
+				Config:             testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", "[]", true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
@@ -56,22 +56,41 @@ type GetQualityProfileActions struct {
 	AssociateProjects bool `json:"associateProjects"`
 }
 
+// GetQualityProfileInheritance for unmarshalling response body of quality profile inheritance
+type GetQualityProfileInheritance struct {
+	Profile   QualityProfileInheritance   `json:"profile"`
+	Ancestors []QualityProfileInheritance `json:"ancestors"`
+	Children  []QualityProfileInheritance `json:"children"`
+}
+
+// QualityProfileInheritance used in GetQualityProfileInheritance
+type QualityProfileInheritance struct {
+	Key             string `json:"key"`
+	Name            string `json:"name"`
+	Parent          string `json:"parent"`
+	ActiveRuleCount int    `json:"activeRuleCount"`
+	IsBuiltIn       bool   `json:"isBuiltIn"`
+}
+
 // Returns the resource represented by this file.
 func resourceSonarqubeQualityProfile() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSonarqubeQualityProfileCreate,
 		Read:   resourceSonarqubeQualityProfileRead,
+		Update: resourceSonarqubeQualityProfileUpdate,
 		Delete: resourceSonarqubeQualityProfileDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityProfileImport,
 		},
 
+		// Languages are validated against the languages supported by the server
+		CustomizeDiff: validateLanguageDiff,
+
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "Quality profile name",
 				ValidateDiagFunc: validation.ToDiagFunc(
 					validation.StringLenBetween(0, 100),
@@ -87,45 +106,134 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 				Description: "Quality profile language",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the parent quality profile",
 				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringInSlice(
-						[]string{"cs", "css", "flex", "go", "java", "js", "jsp", "kotlin", "php", "py", "ruby", "scala", "ts", "vbnet", "web", "xml"},
-						false,
-					),
+					validation.StringLenBetween(0, 100),
 				),
 			},
+			"is_inherited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the quality profile inherits from a parent quality profile",
+			},
+			"active_rule_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of active rules in the quality profile",
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the quality profile is the default quality profile of its language, unmanaged when unset",
+			},
+			"copy_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Key of the quality profile the new quality profile is copied from",
+			},
 		},
 	}
 }
 
 func resourceSonarqubeQualityProfileCreate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = "api/qualityprofiles/create"
-	sonarQubeURL.RawQuery = url.Values{
-		"name":     []string{d.Get("name").(string)},
-		"language": []string{d.Get("language").(string)},
-	}.Encode()
+	if copyFrom, ok := d.GetOk("copy_from"); ok {
+		// Check the language before copying, a copy with the wrong language would be left behind untracked
+		source, err := getQualityProfile(copyFrom.(string), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: Failed to read quality profile %s: %+v", copyFrom.(string), err)
+		}
+		if source == nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: Quality profile %s to copy from not found", copyFrom.(string))
+		}
+		if source.Language != d.Get("language").(string) {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: Quality profile %s has language %s instead of %s", copyFrom.(string), source.Language, d.Get("language").(string))
+		}
 
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceSonarqubeQualityProfileCreate",
-	)
-	if err != nil {
-		return err
+		// Copying onto an existing quality profile overwrites its rules, which would take over an unmanaged profile
+		existing, err := getQualityProfileByName(d.Get("name").(string), d.Get("language").(string), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: %+v", err)
+		}
+		if existing != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: Quality profile %s already exists for language %s", d.Get("name").(string), d.Get("language").(string))
+		}
+
+		// Create the quality profile as a copy of an existing one
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = "api/qualityprofiles/copy"
+		sonarQubeURL.RawQuery = url.Values{
+			"fromKey": []string{copyFrom.(string)},
+			"toName":  []string{d.Get("name").(string)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"resourceSonarqubeQualityProfileCreate",
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// Decode response into struct
+		copyQualityProfileResponse := QualityProfile{}
+		err = json.NewDecoder(resp.Body).Decode(&copyQualityProfileResponse)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: Failed to decode json into struct: %+v", err)
+		}
+
+		d.SetId(copyQualityProfileResponse.Key)
+	} else {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = "api/qualityprofiles/create"
+		sonarQubeURL.RawQuery = url.Values{
+			"name":     []string{d.Get("name").(string)},
+			"language": []string{d.Get("language").(string)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"resourceSonarqubeQualityProfileCreate",
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// Decode response into struct
+		qualityProfileResponse := CreateQualityProfileResponse{}
+		err = json.NewDecoder(resp.Body).Decode(&qualityProfileResponse)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: Failed to decode json into struct: %+v", err)
+		}
+
+		d.SetId(qualityProfileResponse.Profile.Key)
 	}
-	defer resp.Body.Close()
 
-	// Decode response into struct
-	qualityProfileResponse := CreateQualityProfileResponse{}
-	err = json.NewDecoder(resp.Body).Decode(&qualityProfileResponse)
-	if err != nil {
-		return fmt.Errorf("resourceSonarqubeQualityProfileCreate: Failed to decode json into struct: %+v", err)
+	if _, ok := d.GetOk("parent"); ok {
+		if err := qualityProfileChangeParent(d, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: %+v", err)
+		}
+	}
+
+	if d.Get("is_default").(bool) {
+		if err := qualityProfileSetDefault(d.Get("name").(string), d.Get("language").(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileCreate: %+v", err)
+		}
 	}
 
-	d.SetId(qualityProfileResponse.Profile.Key)
 	return resourceSonarqubeQualityProfileRead(d, m)
 }
 
@@ -152,20 +260,113 @@ func resourceSonarqubeQualityProfileRead(d *schema.ResourceData, m interface{})
 		return fmt.Errorf("resourceSonarqubeQualityProfileRead: Failed to decode json into struct: %+v", err)
 	}
 
+	readSuccess := false
 	for _, value := range getQualityProfileResponse.Profiles {
 		if d.Id() == value.Key {
 			d.SetId(value.Key)
 			d.Set("name", value.Name)
 			d.Set("language", value.Language)
 			d.Set("key", value.Key)
+			d.Set("is_inherited", value.IsInherited)
+			d.Set("active_rule_count", value.ActiveRuleCount)
+			d.Set("is_default", value.IsDefault)
+			readSuccess = true
 		}
 	}
 
+	if !readSuccess {
+		// Quality profile not found
+		d.SetId("")
+		return nil
+	}
+
+	// Read the parent from the inheritance tree of the quality profile
+	sonarQubeURL.Path = "api/qualityprofiles/inheritance"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{d.Get("name").(string)},
+		"language":       []string{d.Get("language").(string)},
+	}.Encode()
+
+	resp, err = httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileInheritance := GetQualityProfileInheritance{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileInheritance)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRead: Failed to decode json into struct: %+v", err)
+	}
+
+	// The ancestors are ordered from the direct parent to the root
+	parent := ""
+	if len(getQualityProfileInheritance.Ancestors) > 0 {
+		parent = getQualityProfileInheritance.Ancestors[0].Name
+	}
+	d.Set("parent", parent)
+
 	return nil
 
 }
 
+func resourceSonarqubeQualityProfileUpdate(d *schema.ResourceData, m interface{}) error {
+	// Rename the quality profile first, the other updates address it by name
+	if d.HasChange("name") {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = "api/qualityprofiles/rename"
+		sonarQubeURL.RawQuery = url.Values{
+			"key":  []string{d.Id()},
+			"name": []string{d.Get("name").(string)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusNoContent,
+			"resourceSonarqubeQualityProfileUpdate",
+		)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: Failed to rename quality profile: %+v", err)
+		}
+		defer resp.Body.Close()
+	}
+
+	if d.HasChange("parent") {
+		if err := qualityProfileChangeParent(d, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: %+v", err)
+		}
+	}
+
+	if d.HasChange("is_default") {
+		if err := qualityProfileToggleDefault(d, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeQualityProfileRead(d, m)
+}
+
 func resourceSonarqubeQualityProfileDelete(d *schema.ResourceData, m interface{}) error {
+	// The default quality profile of a language can't be deleted
+	if d.Get("is_default").(bool) {
+		builtIn, err := builtInQualityProfileName(d.Get("language").(string), m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileDelete: %+v", err)
+		}
+		if err := qualityProfileSetDefault(builtIn, d.Get("language").(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileDelete: %+v", err)
+		}
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = "api/qualityprofiles/delete"
 	sonarQubeURL.RawQuery = url.Values{
@@ -195,3 +396,176 @@ func resourceSonarqubeQualityProfileImport(d *schema.ResourceData, m interface{}
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// qualityProfileChangeParent sets the configured parent of the quality profile, an empty parent removes the inheritance
+func qualityProfileChangeParent(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/change_parent"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile":       []string{d.Get("name").(string)},
+		"language":             []string{d.Get("language").(string)},
+		"parentQualityProfile": []string{d.Get("parent").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"qualityProfileChangeParent",
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to change parent of quality profile: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// qualityProfileToggleDefault makes the quality profile the default of its language.
+// As a language always has a default quality profile, unsetting it restores the built-in quality profile as default.
+func qualityProfileToggleDefault(d *schema.ResourceData, m interface{}) error {
+	language := d.Get("language").(string)
+	if d.Get("is_default").(bool) {
+		return qualityProfileSetDefault(d.Get("name").(string), language, m)
+	}
+
+	builtIn, err := builtInQualityProfileName(language, m)
+	if err != nil {
+		return err
+	}
+	return qualityProfileSetDefault(builtIn, language, m)
+}
+
+// qualityProfileSetDefault sets the named quality profile as default of the language
+func qualityProfileSetDefault(name string, language string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/set_default"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{name},
+		"language":       []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"qualityProfileSetDefault",
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to set default quality profile: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// builtInQualityProfileName returns the name of the built-in quality profile of the language, preferring "Sonar way"
+func builtInQualityProfileName(language string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"language": []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"builtInQualityProfileName",
+	)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return "", fmt.Errorf("builtInQualityProfileName: Failed to decode json into struct: %+v", err)
+	}
+
+	builtIn := ""
+	for _, value := range getQualityProfileResponse.Profiles {
+		if value.IsBuiltIn && (builtIn == "" || value.Name == "Sonar way") {
+			builtIn = value.Name
+		}
+	}
+
+	if builtIn == "" {
+		return "", fmt.Errorf("No built-in quality profile found for language %s", language)
+	}
+	return builtIn, nil
+}
+
+// getQualityProfile returns the quality profile with the given key, or nil if it doesn't exist
+func getQualityProfile(key string, m interface{}) (*GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/search"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getQualityProfile",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return nil, fmt.Errorf("getQualityProfile: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, value := range getQualityProfileResponse.Profiles {
+		if value.Key == key {
+			return &value, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getQualityProfileByName returns the quality profile with the given name and language, or nil if it doesn't exist
+func getQualityProfileByName(name string, language string, m interface{}) (*GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"language": []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getQualityProfileByName",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return nil, fmt.Errorf("getQualityProfileByName: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, value := range getQualityProfileResponse.Profiles {
+		if value.Name == name && value.Language == language {
+			return &value, nil
+		}
+	}
+
+	return nil, nil
+}
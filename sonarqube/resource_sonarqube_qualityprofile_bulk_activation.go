@@ -0,0 +1,305 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/satori/uuid"
+)
+
+// BulkRuleChangeResponse for unmarshalling response body of bulk rule (de)activation
+type BulkRuleChangeResponse struct {
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+}
+
+// ruleQueryFilters maps the rule query attributes to the parameters of api/rules/search
+var ruleQueryFilters = map[string]string{
+	"languages":            "languages",
+	"repositories":         "repositories",
+	"severities":           "severities",
+	"tags":                 "tags",
+	"types":                "types",
+	"cwe":                  "cwe",
+	"owasp_top10":          "owaspTop10",
+	"sans_top25":           "sansTop25",
+	"sonarsource_security": "sonarsourceSecurity",
+}
+
+// ruleSeverities are the severities rules can be activated with
+var ruleSeverities = []string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileBulkActivation() *schema.Resource {
+	filterKeys := make([]string, 0, len(ruleQueryFilters))
+	for key := range ruleQueryFilters {
+		filterKeys = append(filterKeys, key)
+	}
+	sort.Strings(filterKeys)
+
+	resourceSchema := map[string]*schema.Schema{
+		"quality_profile_key": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Key of the quality profile the rules are (de)activated in",
+		},
+		"deactivate": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+			Description: "Deactivate the matching rules instead of activating them",
+		},
+		"target_severity": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Severity to set on the activated rules",
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(ruleSeverities, false),
+			),
+		},
+		"matched_rules": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of rules matching the query",
+		},
+	}
+
+	for _, key := range filterKeys {
+		resourceSchema[key] = &schema.Schema{
+			Type:         schema.TypeSet,
+			Optional:     true,
+			ForceNew:     true,
+			AtLeastOneOf: filterKeys,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityProfileBulkActivationCreate,
+		Read:   resourceSonarqubeQualityProfileBulkActivationRead,
+		Delete: resourceSonarqubeQualityProfileBulkActivationDelete,
+
+		// Define the fields of this schema.
+		Schema: resourceSchema,
+	}
+}
+
+func resourceSonarqubeQualityProfileBulkActivationCreate(d *schema.ResourceData, m interface{}) error {
+	profile, err := getQualityProfile(d.Get("quality_profile_key").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationCreate: Failed to read quality profile: %+v", err)
+	}
+	if profile == nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationCreate: Quality profile %s not found", d.Get("quality_profile_key").(string))
+	}
+
+	rawQuery, err := bulkActivationRuleQuery(d, profile.Language)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationCreate: %+v", err)
+	}
+	rawQuery.Add("targetKey", d.Get("quality_profile_key").(string))
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	if d.Get("deactivate").(bool) {
+		sonarQubeURL.Path = "api/qualityprofiles/deactivate_rules"
+	} else {
+		sonarQubeURL.Path = "api/qualityprofiles/activate_rules"
+		if severity, ok := d.GetOk("target_severity"); ok {
+			rawQuery.Add("targetSeverity", severity.(string))
+		}
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileBulkActivationCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationCreate: Failed to change rules: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	bulkResponse := BulkRuleChangeResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&bulkResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationCreate: Failed to decode json into struct: %+v", err)
+	}
+
+	// generate a unique ID
+	d.SetId(uuid.NewV4().String())
+
+	// The rules which were changed stay tracked, the resource is tainted and applied again
+	if bulkResponse.Failed > 0 {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationCreate: %d of %d rules could not be changed in quality profile %s", bulkResponse.Failed, bulkResponse.Failed+bulkResponse.Succeeded, d.Get("quality_profile_key").(string))
+	}
+
+	return resourceSonarqubeQualityProfileBulkActivationRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileBulkActivationRead(d *schema.ResourceData, m interface{}) error {
+	profile, err := getQualityProfile(d.Get("quality_profile_key").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationRead: Failed to read quality profile: %+v", err)
+	}
+	if profile == nil {
+		// The quality profile and with it the activation is gone
+		d.SetId("")
+		return nil
+	}
+
+	// Count all rules matching the query
+	rawQuery, err := bulkActivationRuleQuery(d, profile.Language)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationRead: %+v", err)
+	}
+	matchedRules, err := countRules(rawQuery, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationRead: %+v", err)
+	}
+	d.Set("matched_rules", matchedRules)
+
+	// Count the matching rules which are not in the desired state in the quality profile.
+	// This happens when rules are changed outside of terraform or newly installed rules match the query.
+	rawQuery, _ = bulkActivationRuleQuery(d, profile.Language)
+	rawQuery.Add("qprofile", d.Get("quality_profile_key").(string))
+	rawQuery.Add("activation", strconv.FormatBool(d.Get("deactivate").(bool)))
+	if d.Get("deactivate").(bool) {
+		// Rules inherited from the parent profile can't be deactivated
+		rawQuery.Add("inheritance", "NONE")
+	}
+	pendingRules, err := countRules(rawQuery, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationRead: %+v", err)
+	}
+
+	// Count the activated rules whose severity was changed away from the target severity
+	if severity, ok := d.GetOk("target_severity"); ok && !d.Get("deactivate").(bool) {
+		otherSeverities := make([]string, 0)
+		for _, value := range ruleSeverities {
+			if value != severity.(string) {
+				otherSeverities = append(otherSeverities, value)
+			}
+		}
+
+		rawQuery, _ = bulkActivationRuleQuery(d, profile.Language)
+		rawQuery.Add("qprofile", d.Get("quality_profile_key").(string))
+		rawQuery.Add("activation", "true")
+		rawQuery.Add("active_severities", strings.Join(otherSeverities, ","))
+		changedRules, err := countRules(rawQuery, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationRead: %+v", err)
+		}
+		pendingRules += changedRules
+	}
+
+	if pendingRules > 0 {
+		log.Printf("[INFO] resourceSonarqubeQualityProfileBulkActivationRead: %d matching rules are out of sync in quality profile %s", pendingRules, d.Get("quality_profile_key").(string))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfileBulkActivationDelete(d *schema.ResourceData, m interface{}) error {
+	// The previous state of deactivated rules is unknown, so they are left as they are
+	if d.Get("deactivate").(bool) {
+		return nil
+	}
+
+	profile, err := getQualityProfile(d.Get("quality_profile_key").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationDelete: Failed to read quality profile: %+v", err)
+	}
+	if profile == nil {
+		// Nothing to deactivate in a deleted quality profile
+		return nil
+	}
+
+	rawQuery, err := bulkActivationRuleQuery(d, profile.Language)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationDelete: %+v", err)
+	}
+	rawQuery.Add("targetKey", d.Get("quality_profile_key").(string))
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/deactivate_rules"
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileBulkActivationDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileBulkActivationDelete: Failed to deactivate rules: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// expandRuleQuery builds the rule search parameters from the configured filters
+func expandRuleQuery(d *schema.ResourceData) url.Values {
+	rawQuery := url.Values{}
+	for key, param := range ruleQueryFilters {
+		if value, ok := d.GetOk(key); ok {
+			rawQuery.Add(param, strings.Join(expandStringSet(value.(*schema.Set)), ","))
+		}
+	}
+	return rawQuery
+}
+
+// bulkActivationRuleQuery builds the rule search parameters of the activation. Only rules of the
+// quality profile language can be activated, so the query is limited to it. Rule templates are
+// excluded as they can't be activated.
+func bulkActivationRuleQuery(d *schema.ResourceData, language string) (url.Values, error) {
+	if languages, ok := d.GetOk("languages"); ok && !languages.(*schema.Set).Contains(language) {
+		return nil, fmt.Errorf("languages must contain the quality profile language %s", language)
+	}
+
+	rawQuery := expandRuleQuery(d)
+	rawQuery.Set("languages", language)
+	rawQuery.Set("is_template", "false")
+	return rawQuery, nil
+}
+
+// countRules returns the number of rules matching the given api/rules/search parameters
+func countRules(rawQuery url.Values, m interface{}) (int64, error) {
+	rawQuery.Set("ps", "1")
+
+	rulesResponse, err := searchRules(rawQuery, m)
+	if err != nil {
+		return 0, err
+	}
+
+	return rulesResponse.Total, nil
+}
+
+// expandStringSet converts a schema.Set of strings into a sorted string slice
+func expandStringSet(set *schema.Set) []string {
+	expanded := make([]string, 0, set.Len())
+	for _, value := range set.List() {
+		expanded = append(expanded, value.(string))
+	}
+	sort.Strings(expanded)
+	return expanded
+}
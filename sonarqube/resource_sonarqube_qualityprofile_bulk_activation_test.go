@@ -0,0 +1,106 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_bulk_activation", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_bulk_activation",
+		F:    testSweepSonarqubeQualityProfileBulkActivationSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityProfileBulkActivationSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityProfileBulkActivationBasicConfig(rnd string, name string, language string, types []string) string {
+	formattedTypes := generateHCLList(types)
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "%[3]s"
+		}
+
+		resource "sonarqube_qualityprofile_bulk_activation" "%[1]s" {
+			quality_profile_key = sonarqube_qualityprofile.%[1]s.key
+			languages           = ["%[3]s"]
+			types               = %[4]s
+			target_severity     = "MAJOR"
+		}`, rnd, name, language, formattedTypes)
+}
+
+func TestAccSonarqubeQualityProfileBulkActivationBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_bulk_activation." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileBulkActivationBasicConfig(rnd, "testAccSonarqubeQualityProfileBulkActivation", "js", []string{"BUG"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "languages.#", "1"),
+					resource.TestCheckResourceAttr(name, "types.#", "1"),
+					resource.TestCheckResourceAttrSet(name, "matched_rules"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileBulkActivationBasicConfig(rnd, "testAccSonarqubeQualityProfileBulkActivation", "js", []string{"BUG", "VULNERABILITY"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "types.#", "2"),
+					resource.TestCheckResourceAttrSet(name, "matched_rules"),
+				),
+			},
+			{
+				// The activated rules have the target severity, so no drift is detected
+				Config:             testAccSonarqubeQualityProfileBulkActivationBasicConfig(rnd, "testAccSonarqubeQualityProfileBulkActivation", "js", []string{"BUG", "VULNERABILITY"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileBulkActivationTagsConfig(rnd string, name string, language string, tags []string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "%[3]s"
+		}
+
+		resource "sonarqube_qualityprofile_bulk_activation" "%[1]s" {
+			quality_profile_key = sonarqube_qualityprofile.%[1]s.key
+			tags                = %[4]s
+		}`, rnd, name, language, generateHCLList(tags))
+}
+
+func TestAccSonarqubeQualityProfileBulkActivationTags(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_bulk_activation." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Rules of other languages share the tag, the query is limited to the profile language
+				Config: testAccSonarqubeQualityProfileBulkActivationTagsConfig(rnd, "testAccSonarqubeQualityProfileBulkActivationTags", "js", []string{"cwe"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tags.#", "1"),
+					resource.TestCheckResourceAttrSet(name, "matched_rules"),
+				),
+			},
+			{
+				Config:             testAccSonarqubeQualityProfileBulkActivationTagsConfig(rnd, "testAccSonarqubeQualityProfileBulkActivationTags", "js", []string{"cwe"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
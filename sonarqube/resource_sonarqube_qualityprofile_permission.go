@@ -0,0 +1,239 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/satori/uuid"
+)
+
+// GetQualityProfileUsers for unmarshalling response body of quality profile search_users
+type GetQualityProfileUsers struct {
+	Paging Paging                    `json:"paging"`
+	Users  []QualityProfilePrincipal `json:"users"`
+}
+
+// GetQualityProfileGroups for unmarshalling response body of quality profile search_groups
+type GetQualityProfileGroups struct {
+	Paging Paging                    `json:"paging"`
+	Groups []QualityProfilePrincipal `json:"groups"`
+}
+
+// QualityProfilePrincipal used in GetQualityProfileUsers and GetQualityProfileGroups
+type QualityProfilePrincipal struct {
+	Login       string `json:"login,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Selected    bool   `json:"selected"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfilePermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityProfilePermissionCreate,
+		Read:   resourceSonarqubeQualityProfilePermissionRead,
+		Delete: resourceSonarqubeQualityProfilePermissionDelete,
+
+		// Languages are validated against the languages supported by the server
+		CustomizeDiff: validateLanguageDiff,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"quality_profile": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality profile name",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringLenBetween(0, 100),
+				),
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality profile language",
+			},
+			"login_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"login_name", "group_name"},
+				Description:  "Login of the user allowed to edit the quality profile",
+			},
+			"group_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"login_name", "group_name"},
+				Description:  "Name of the group allowed to edit the quality profile",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfilePermissionCreate(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+
+	rawQuery := url.Values{
+		"qualityProfile": []string{d.Get("quality_profile").(string)},
+		"language":       []string{d.Get("language").(string)},
+	}
+
+	// we use different API endpoints and request params
+	// based on the target principal type (group or user)
+	if login, ok := d.GetOk("login_name"); ok {
+		sonarQubeURL.Path = "api/qualityprofiles/add_user"
+		rawQuery.Add("login", login.(string))
+	} else {
+		sonarQubeURL.Path = "api/qualityprofiles/add_group"
+		rawQuery.Add("group", d.Get("group_name").(string))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeQualityProfilePermissionCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("Error creating Sonarqube quality profile permission: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// generate a unique ID
+	d.SetId(uuid.NewV4().String())
+	return resourceSonarqubeQualityProfilePermissionRead(d, m)
+}
+
+func resourceSonarqubeQualityProfilePermissionRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+
+	rawQuery := url.Values{
+		"qualityProfile": []string{d.Get("quality_profile").(string)},
+		"language":       []string{d.Get("language").(string)},
+		"selected":       []string{"selected"},
+		"ps":             []string{"100"},
+	}
+
+	readSuccess := false
+	if login, ok := d.GetOk("login_name"); ok {
+		// permission target is USER
+		sonarQubeURL.Path = "api/qualityprofiles/search_users"
+		rawQuery.Add("q", login.(string))
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"resourceSonarqubeQualityProfilePermissionRead",
+		)
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				// Quality profile, user or group not found
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("Error reading Sonarqube quality profile permission: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		// Decode response into struct
+		users := GetQualityProfileUsers{}
+		err = json.NewDecoder(resp.Body).Decode(&users)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfilePermissionRead: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, value := range users.Users {
+			if value.Login == login.(string) && value.Selected {
+				readSuccess = true
+			}
+		}
+	} else {
+		// permission target is GROUP
+		groupName := d.Get("group_name").(string)
+		sonarQubeURL.Path = "api/qualityprofiles/search_groups"
+		rawQuery.Add("q", groupName)
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"resourceSonarqubeQualityProfilePermissionRead",
+		)
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				// Quality profile, user or group not found
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("Error reading Sonarqube quality profile permission: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		// Decode response into struct
+		groups := GetQualityProfileGroups{}
+		err = json.NewDecoder(resp.Body).Decode(&groups)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfilePermissionRead: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, value := range groups.Groups {
+			if value.Name == groupName && value.Selected {
+				readSuccess = true
+			}
+		}
+	}
+
+	if !readSuccess {
+		// Permission not found
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfilePermissionDelete(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+
+	rawQuery := url.Values{
+		"qualityProfile": []string{d.Get("quality_profile").(string)},
+		"language":       []string{d.Get("language").(string)},
+	}
+
+	// we use different API endpoints and request params
+	// based on the target principal type (group or user)
+	if login, ok := d.GetOk("login_name"); ok {
+		sonarQubeURL.Path = "api/qualityprofiles/remove_user"
+		rawQuery.Add("login", login.(string))
+	} else {
+		sonarQubeURL.Path = "api/qualityprofiles/remove_group"
+		rawQuery.Add("group", d.Get("group_name").(string))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeQualityProfilePermissionDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("Error deleting Sonarqube quality profile permission: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_permission", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_permission",
+		F:    testSweepSonarqubeQualityProfilePermissionSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityProfilePermissionSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityProfilePermissionConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+
+		resource "sonarqube_qualityprofile_permission" "%[1]s_group" {
+			quality_profile = sonarqube_qualityprofile.%[1]s.name
+			language        = sonarqube_qualityprofile.%[1]s.language
+			group_name      = sonarqube_group.%[1]s.name
+		}
+
+		resource "sonarqube_qualityprofile_permission" "%[1]s_user" {
+			quality_profile = sonarqube_qualityprofile.%[1]s.name
+			language        = sonarqube_qualityprofile.%[1]s.language
+			login_name      = sonarqube_user.%[1]s.login_name
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfilePermission(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_permission." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfilePermissionConfig(rnd, "testAccSonarqubeQualityProfilePermission"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name+"_group", "group_name", "testAccSonarqubeQualityProfilePermission"),
+					resource.TestCheckResourceAttr(name+"_user", "login_name", "testAccSonarqubeQualityProfilePermission"),
+				),
+			},
+		},
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -35,6 +36,9 @@ func resourceSonarqubeQualityProfileProjectAssociation() *schema.Resource {
 			State: resourceSonarqubeQualityProfileProjectAssociationImport,
 		},
 
+		// Languages are validated against the languages supported by the server
+		CustomizeDiff: validateLanguageDiff,
+
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"quality_profile": {
@@ -50,9 +54,9 @@ func resourceSonarqubeQualityProfileProjectAssociation() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "Project name",
+				Description: "Project key",
 				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringLenBetween(0, 100),
+					validation.StringLenBetween(0, 400),
 				),
 			},
 			"language": {
@@ -60,12 +64,6 @@ func resourceSonarqubeQualityProfileProjectAssociation() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 				Description: "Quality profile language",
-				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringInSlice(
-						[]string{"cs", "css", "flex", "go", "java", "js", "jsp", "kotlin", "php", "py", "ruby", "scala", "ts", "vbnet", "web", "xml"},
-						false,
-					),
-				),
 			},
 		},
 	}
@@ -92,23 +90,22 @@ func resourceSonarqubeQualityProfileProjectAssociationCreate(d *schema.ResourceD
 	}
 	defer resp.Body.Close()
 
-	id := fmt.Sprintf("%v/%v", d.Get("quality_profile").(string), d.Get("project").(string))
+	id := fmt.Sprintf("%v/%v/%v", d.Get("quality_profile").(string), d.Get("project").(string), d.Get("language").(string))
 	d.SetId(id)
 	return resourceSonarqubeQualityProfileProjectAssociationRead(d, m)
 }
 
 func resourceSonarqubeQualityProfileProjectAssociationRead(d *schema.ResourceData, m interface{}) error {
-	var language string
-	var qualityProfile string
-
-	// Id is composed of qualityProfile name and project name
-	idSlice := strings.Split(d.Id(), "/")
+	qualityProfile := d.Get("quality_profile").(string)
+	project := d.Get("project").(string)
+	language := d.Get("language").(string)
 
 	// Call api/qualityprofiles/search to return the qualityProfileID
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = "api/qualityprofiles/search"
 	sonarQubeURL.RawQuery = url.Values{
-		"qualityProfile": []string{idSlice[0]},
+		"qualityProfile": []string{qualityProfile},
+		"language":       []string{language},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -130,48 +127,57 @@ func resourceSonarqubeQualityProfileProjectAssociationRead(d *schema.ResourceDat
 		return fmt.Errorf("resourceSonarqubeQualityProfileProjectAssociationRead: Failed to decode json into struct: %+v", err)
 	}
 
-	var qualityProfileID string
+	qualityProfileID := ""
 	for _, value := range getQualityProfileResponse.Profiles {
-		qualityProfileID = value.Key
-		language = value.Language
-		qualityProfile = value.Name
+		if value.Name == qualityProfile && value.Language == language {
+			qualityProfileID = value.Key
+		}
 	}
 
-	// With the qualityProfileID we can check if the project name is associated
-	sonarQubeURL.Path = "api/qualityprofiles/projects"
-	sonarQubeURL.RawQuery = url.Values{
-		"key": []string{qualityProfileID},
-	}.Encode()
-
-	resp, err = httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"GET",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceSonarqubeQualityProfileProjectAssociationRead",
-	)
-	if err != nil {
-		return err
+	if qualityProfileID == "" {
+		// Quality profile not found
+		d.SetId("")
+		return nil
 	}
-	defer resp.Body.Close()
 
-	// Decode response into struct
-	getQualityProfileProjectResponse := GetQualityProfileProjectAssociation{}
-	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileProjectResponse)
-	if err != nil {
-		return fmt.Errorf("resourceSonarqubeQualityProfileProjectAssociationRead: Failed to decode json into struct: %+v", err)
+	// With the qualityProfileID we can check if the project key is associated
+	sonarQubeURL.Path = "api/qualityprofiles/projects"
+	rawQuery := url.Values{
+		"key":      []string{qualityProfileID},
+		"selected": []string{"selected"},
+		"ps":       []string{"500"},
 	}
 
-	for _, value := range getQualityProfileProjectResponse.Results {
-		if idSlice[1] == value.Name {
-			d.Set("project", value.Name)
-			d.Set("quality_profile", qualityProfile)
-			d.Set("language", language)
+	readSuccess := false
+	for page := int64(1); !readSuccess; page++ {
+		rawQuery.Set("p", strconv.FormatInt(page, 10))
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		getQualityProfileProjectResponse, err := getQualityProfileProjectsPage(sonarQubeURL, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeQualityProfileProjectAssociationRead: %+v", err)
+		}
+
+		for _, value := range getQualityProfileProjectResponse.Results {
+			if value.Key == project && value.Selected {
+				readSuccess = true
+			}
+		}
+
+		paging := getQualityProfileProjectResponse.Paging
+		if len(getQualityProfileProjectResponse.Results) == 0 || paging.PageIndex*paging.PageSize >= paging.Total {
+			break
 		}
 	}
 
-	return nil
+	if !readSuccess {
+		// Association not found
+		d.SetId("")
+		return nil
+	}
 
+	d.SetId(fmt.Sprintf("%v/%v/%v", qualityProfile, project, language))
+	return nil
 }
 
 func resourceSonarqubeQualityProfileProjectAssociationDelete(d *schema.ResourceData, m interface{}) error {
@@ -200,8 +206,46 @@ func resourceSonarqubeQualityProfileProjectAssociationDelete(d *schema.ResourceD
 }
 
 func resourceSonarqubeQualityProfileProjectAssociationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceSonarqubeQualityProfileRead(d, m); err != nil {
+	// Id is composed of quality profile name, project key and language.
+	// Quality profile names may contain slashes, project keys and languages can't.
+	id := d.Id()
+	idSlice := strings.Split(id, "/")
+	if len(idSlice) < 3 {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileProjectAssociationImport: Invalid id %q, expected quality_profile/project/language", id)
+	}
+	d.Set("quality_profile", strings.Join(idSlice[:len(idSlice)-2], "/"))
+	d.Set("project", idSlice[len(idSlice)-2])
+	d.Set("language", idSlice[len(idSlice)-1])
+
+	if err := resourceSonarqubeQualityProfileProjectAssociationRead(d, m); err != nil {
 		return nil, err
 	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileProjectAssociationImport: Association %q not found", id)
+	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// getQualityProfileProjectsPage returns a page of the projects of a quality profile
+func getQualityProfileProjectsPage(sonarQubeURL url.URL, m interface{}) (GetQualityProfileProjectAssociation, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getQualityProfileProjectsPage",
+	)
+	if err != nil {
+		return GetQualityProfileProjectAssociation{}, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	projects := GetQualityProfileProjectAssociation{}
+	err = json.NewDecoder(resp.Body).Decode(&projects)
+	if err != nil {
+		return GetQualityProfileProjectAssociation{}, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return projects, nil
+}
@@ -27,13 +27,13 @@ func testAccSonarqubeQualityProfileProjectAssociationBasicConfig(rnd string, nam
 
 		resource "sonarqube_project" "%[1]s" {
 			name       = "%[2]s"
-			project    = "%[2]s"
+			project    = "%[2]s-key"
 			visibility = "public" 
 		}
 
 		resource "sonarqube_qualityprofile_project_association" "%[1]s" {
 			quality_profile = sonarqube_qualityprofile.%[1]s.name
-			project         = sonarqube_project.%[1]s.name
+			project         = sonarqube_project.%[1]s.project
 			language        = "%[3]s"
 		}`, rnd, name, language)
 }
@@ -50,17 +50,18 @@ func TestAccSonarqubeQualityProfileProjectAssociationBasic(t *testing.T) {
 				Config: testAccSonarqubeQualityProfileProjectAssociationBasicConfig(rnd, "testAccSonarqubeProfileProjectAssociation", "js"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "quality_profile", "testAccSonarqubeProfileProjectAssociation"),
-					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProfileProjectAssociation"),
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProfileProjectAssociation-key"),
 					resource.TestCheckResourceAttr(name, "language", "js"),
 				),
 			},
 			{
 				ResourceName:      name,
 				ImportState:       true,
+				ImportStateId:     "testAccSonarqubeProfileProjectAssociation/testAccSonarqubeProfileProjectAssociation-key/js",
 				ImportStateVerify: true,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "quality_profile", "testAccSonarqubeProfileProjectAssociation"),
-					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProfileProjectAssociation"),
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProfileProjectAssociation-key"),
 					resource.TestCheckResourceAttr(name, "language", "js"),
 				),
 			},
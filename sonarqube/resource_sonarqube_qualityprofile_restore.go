@@ -0,0 +1,420 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// RestoreQualityProfileResponse for unmarshalling response body of quality profile restore
+type RestoreQualityProfileResponse struct {
+	Profile       QualityProfile `json:"profile"`
+	RuleSuccesses int            `json:"ruleSuccesses"`
+	RuleFailures  int            `json:"ruleFailures"`
+}
+
+// QualityProfileBackup for unmarshalling the quality profile backup xml
+type QualityProfileBackup struct {
+	XMLName  xml.Name                   `xml:"profile"`
+	Name     string                     `xml:"name"`
+	Language string                     `xml:"language"`
+	Rules    []QualityProfileBackupRule `xml:"rules>rule"`
+}
+
+// QualityProfileBackupRule used in QualityProfileBackup. The rule type is left out as it is not part of the quality profile.
+type QualityProfileBackupRule struct {
+	RepositoryKey string                          `xml:"repositoryKey"`
+	Key           string                          `xml:"key"`
+	Priority      string                          `xml:"priority"`
+	Name          string                          `xml:"name,omitempty"`
+	TemplateKey   string                          `xml:"templateKey,omitempty"`
+	Description   string                          `xml:"description,omitempty"`
+	Parameters    []QualityProfileBackupParameter `xml:"parameters>parameter"`
+}
+
+// QualityProfileBackupParameter used in QualityProfileBackupRule
+type QualityProfileBackupParameter struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileRestore() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeQualityProfileRestoreCreate,
+		Read:   resourceSonarqubeQualityProfileRestoreRead,
+		Update: resourceSonarqubeQualityProfileRestoreUpdate,
+		Delete: resourceSonarqubeQualityProfileRestoreDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileRestoreImport,
+		},
+
+		// The profile name and language are part of the backup, restoring another profile needs a new resource
+		CustomizeDiff: customdiff.ForceNewIfChange("backup", func(ctx context.Context, old, new, meta interface{}) bool {
+			oldBackup, err := parseQualityProfileBackup(old.(string))
+			if err != nil {
+				return false
+			}
+			newBackup, err := parseQualityProfileBackup(new.(string))
+			if err != nil {
+				return false
+			}
+			return oldBackup.Name != newBackup.Name || oldBackup.Language != newBackup.Language
+		}),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"backup": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Quality profile backup XML",
+				ValidateDiagFunc: validation.ToDiagFunc(func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := parseQualityProfileBackup(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%q must be a valid quality profile backup: %+v", key, err))
+					}
+					return
+				}),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					oldBackup, err := normalizeQualityProfileBackup(old)
+					if err != nil {
+						return false
+					}
+					newBackup, err := normalizeQualityProfileBackup(new)
+					if err != nil {
+						return false
+					}
+					return oldBackup == newBackup
+				},
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Quality profile key",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Quality profile name",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Quality profile language",
+			},
+			"rule_successes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of rules restored by the last restore",
+			},
+			"rule_failures": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of rules which failed to restore in the last restore",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfileRestoreCreate(d *schema.ResourceData, m interface{}) error {
+	// Restoring over an existing quality profile would take over an unmanaged profile
+	backup, err := parseQualityProfileBackup(d.Get("backup").(string))
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreCreate: Failed to parse quality profile backup: %+v", err)
+	}
+	existing, err := getQualityProfileByName(backup.Name, backup.Language, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreCreate: %+v", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreCreate: Quality profile %s already exists for language %s, import it instead", backup.Name, backup.Language)
+	}
+
+	key, err := qualityProfileRestore(d, m)
+	if key != "" {
+		// The restored profile stays tracked even when some rules failed, the resource is tainted and applied again
+		d.SetId(key)
+	}
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreCreate: %+v", err)
+	}
+
+	return resourceSonarqubeQualityProfileRestoreRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRestoreRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/search"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileRestoreRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	getQualityProfileResponse := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&getQualityProfileResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreRead: Failed to decode json into struct: %+v", err)
+	}
+
+	readSuccess := false
+	for _, value := range getQualityProfileResponse.Profiles {
+		if d.Id() == value.Key {
+			d.Set("key", value.Key)
+			d.Set("name", value.Name)
+			d.Set("language", value.Language)
+			readSuccess = true
+		}
+	}
+
+	if !readSuccess {
+		// Quality profile not found
+		d.SetId("")
+		return nil
+	}
+
+	// Read the current backup so changes made outside of terraform show up in the plan
+	backup, err := qualityProfileBackup(d.Get("name").(string), d.Get("language").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreRead: %+v", err)
+	}
+
+	// The server backup contains every parameter, a configured backup usually leaves out the defaults
+	backup, err = removeDefaultBackupParameters(backup, d.Get("backup").(string), d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreRead: %+v", err)
+	}
+	d.Set("backup", backup)
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRestoreUpdate(d *schema.ResourceData, m interface{}) error {
+	if _, err := qualityProfileRestore(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeQualityProfileRestoreRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileRestoreDelete(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/delete"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{d.Get("name").(string)},
+		"language":       []string{d.Get("language").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeQualityProfileRestoreDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileRestoreDelete: Failed to delete quality profile: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeQualityProfileRestoreImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeQualityProfileRestoreRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// qualityProfileRestore uploads the configured backup and returns the key of the restored quality profile.
+// Rules which could not be restored are returned as error along with the key.
+func qualityProfileRestore(d *schema.ResourceData, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/restore"
+
+	resp, err := httpMultipartRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		"backup",
+		"backup.xml",
+		[]byte(d.Get("backup").(string)),
+		http.StatusOK,
+		"qualityProfileRestore",
+	)
+	if err != nil {
+		return "", fmt.Errorf("Failed to restore quality profile: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	restoreResponse := RestoreQualityProfileResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&restoreResponse)
+	if err != nil {
+		return "", fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	d.Set("rule_successes", restoreResponse.RuleSuccesses)
+	d.Set("rule_failures", restoreResponse.RuleFailures)
+	if restoreResponse.RuleFailures > 0 {
+		return restoreResponse.Profile.Key, fmt.Errorf("%d of %d rules could not be restored in quality profile %s", restoreResponse.RuleFailures, restoreResponse.RuleFailures+restoreResponse.RuleSuccesses, restoreResponse.Profile.Name)
+	}
+	return restoreResponse.Profile.Key, nil
+}
+
+// qualityProfileBackup returns the backup xml of a quality profile
+func qualityProfileBackup(name string, language string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/qualityprofiles/backup"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{name},
+		"language":       []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"qualityProfileBackup",
+	)
+	if err != nil {
+		return "", fmt.Errorf("Failed to backup quality profile: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read quality profile backup: %+v", err)
+	}
+
+	return string(bodyBytes), nil
+}
+
+// removeDefaultBackupParameters removes the parameters from the backup which have their default value
+// and are left out of the previous backup
+func removeDefaultBackupParameters(backup string, previousBackup string, qualityProfileKey string, m interface{}) (string, error) {
+	profile, err := parseQualityProfileBackup(backup)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse quality profile backup: %+v", err)
+	}
+
+	// The previous backup is unknown on import, then every default parameter is removed
+	previousParameters := make(map[string]bool)
+	if previousProfile, err := parseQualityProfileBackup(previousBackup); err == nil {
+		for _, rule := range previousProfile.Rules {
+			for _, parameter := range rule.Parameters {
+				previousParameters[rule.RepositoryKey+":"+rule.Key+"/"+parameter.Key] = true
+			}
+		}
+	}
+
+	defaults, err := activeRuleParameterDefaults(qualityProfileKey, m)
+	if err != nil {
+		return "", err
+	}
+
+	for i, rule := range profile.Rules {
+		ruleKey := rule.RepositoryKey + ":" + rule.Key
+		parameters := make([]QualityProfileBackupParameter, 0, len(rule.Parameters))
+		for _, parameter := range rule.Parameters {
+			defaultValue, hasDefault := defaults[ruleKey][parameter.Key]
+			if hasDefault && defaultValue == parameter.Value && !previousParameters[ruleKey+"/"+parameter.Key] {
+				continue
+			}
+			parameters = append(parameters, parameter)
+		}
+		profile.Rules[i].Parameters = parameters
+	}
+
+	stripped, err := xml.Marshal(profile)
+	if err != nil {
+		return "", err
+	}
+	return string(stripped), nil
+}
+
+// activeRuleParameterDefaults returns the default parameter values of the rules active in a quality profile by rule key
+func activeRuleParameterDefaults(qualityProfileKey string, m interface{}) (map[string]map[string]string, error) {
+	rawQuery := url.Values{
+		"qprofile":   []string{qualityProfileKey},
+		"activation": []string{"true"},
+		"f":          []string{"params"},
+		"ps":         []string{"500"},
+	}
+
+	defaults := make(map[string]map[string]string)
+	for page := int64(1); ; page++ {
+		rawQuery.Set("p", strconv.FormatInt(page, 10))
+
+		rulesResponse, err := searchRules(rawQuery, m)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rulesResponse.Rules {
+			defaults[rule.Key] = make(map[string]string)
+			for _, param := range rule.Params {
+				defaults[rule.Key][param.Key] = param.DefaultValue
+			}
+		}
+
+		if len(rulesResponse.Rules) == 0 || page*rulesResponse.Ps >= rulesResponse.Total {
+			break
+		}
+	}
+
+	return defaults, nil
+}
+
+// parseQualityProfileBackup unmarshals a quality profile backup xml
+func parseQualityProfileBackup(backup string) (QualityProfileBackup, error) {
+	profile := QualityProfileBackup{}
+	err := xml.Unmarshal([]byte(backup), &profile)
+	return profile, err
+}
+
+// normalizeQualityProfileBackup returns the backup xml with rules and parameters in a stable order,
+// so backups only differing in ordering and formatting are equal
+func normalizeQualityProfileBackup(backup string) (string, error) {
+	profile, err := parseQualityProfileBackup(backup)
+	if err != nil {
+		return "", err
+	}
+
+	for _, rule := range profile.Rules {
+		sort.Slice(rule.Parameters, func(i, j int) bool {
+			return rule.Parameters[i].Key < rule.Parameters[j].Key
+		})
+	}
+	sort.Slice(profile.Rules, func(i, j int) bool {
+		if profile.Rules[i].RepositoryKey != profile.Rules[j].RepositoryKey {
+			return profile.Rules[i].RepositoryKey < profile.Rules[j].RepositoryKey
+		}
+		return profile.Rules[i].Key < profile.Rules[j].Key
+	})
+
+	normalized, err := xml.Marshal(profile)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
@@ -0,0 +1,164 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_qualityprofile_restore", &resource.Sweeper{
+		Name: "sonarqube_qualityprofile_restore",
+		F:    testSweepSonarqubeQualityProfileRestoreSweeper,
+	})
+}
+
+func testSweepSonarqubeQualityProfileRestoreSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeQualityProfileRestoreBasicConfig(rnd string, name string, severity string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile_restore" "%[1]s" {
+			backup = <<-EOT
+				<?xml version='1.0' encoding='UTF-8'?>
+				<profile>
+				  <name>%[2]s</name>
+				  <language>js</language>
+				  <rules>
+				    <rule>
+				      <repositoryKey>javascript</repositoryKey>
+				      <key>S1116</key>
+				      <priority>%[3]s</priority>
+				      <parameters/>
+				    </rule>
+				    <rule>
+				      <repositoryKey>javascript</repositoryKey>
+				      <key>S1105</key>
+				      <priority>MINOR</priority>
+				      <parameters>
+				        <parameter>
+				          <key>braceStyle</key>
+				          <value>1tbs</value>
+				        </parameter>
+				      </parameters>
+				    </rule>
+				    <rule>
+				      <repositoryKey>javascript</repositoryKey>
+				      <key>S103</key>
+				      <priority>MAJOR</priority>
+				      <parameters/>
+				    </rule>
+				  </rules>
+				</profile>
+			EOT
+		}`, rnd, name, severity)
+}
+
+func TestAccSonarqubeQualityProfileRestoreBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_restore." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileRestoreBasicConfig(rnd, "testAccSonarqubeQualityProfileRestore", "MINOR"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileRestore"),
+					resource.TestCheckResourceAttr(name, "language", "js"),
+					resource.TestCheckResourceAttr(name, "rule_successes", "3"),
+					resource.TestCheckResourceAttrSet(name, "key"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileRestoreBasicConfig(rnd, "testAccSonarqubeQualityProfileRestore", "MAJOR"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileRestore"),
+					resource.TestCheckResourceAttr(name, "rule_successes", "3"),
+				),
+			},
+			{
+				// The default parameters the server adds to the backup don't cause a diff
+				Config:             testAccSonarqubeQualityProfileRestoreBasicConfig(rnd, "testAccSonarqubeQualityProfileRestore", "MAJOR"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileRestoreExistingConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_restore" "%[1]s" {
+			backup = <<-EOT
+				<?xml version='1.0' encoding='UTF-8'?>
+				<profile>
+				  <name>%[2]s</name>
+				  <language>js</language>
+				  <rules/>
+				</profile>
+			EOT
+
+			depends_on = [sonarqube_qualityprofile.%[1]s]
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileRestoreExisting(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeQualityProfileRestoreExistingConfig(rnd, "testAccSonarqubeQualityProfileRestoreExisting"),
+				ExpectError: regexp.MustCompile("already exists"),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileRestoreUnknownRuleConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile_restore" "%[1]s" {
+			backup = <<-EOT
+				<?xml version='1.0' encoding='UTF-8'?>
+				<profile>
+				  <name>%[2]s</name>
+				  <language>js</language>
+				  <rules>
+				    <rule>
+				      <repositoryKey>javascript</repositoryKey>
+				      <key>doesNotExist</key>
+				      <priority>MAJOR</priority>
+				      <parameters/>
+				    </rule>
+				  </rules>
+				</profile>
+			EOT
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileRestoreUnknownRule(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeQualityProfileRestoreUnknownRuleConfig(rnd, "testAccSonarqubeQualityProfileRestoreUnknownRule"),
+				ExpectError: regexp.MustCompile("could not be restored"),
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package sonarqube
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -54,3 +55,151 @@ func TestAccSonarqubeQualityProfileBasic(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeQualityProfileParentConfig(rnd string, name string, language string, parent string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s_base" {
+			name     = "%[2]s-base"
+			language = "%[3]s"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "%[3]s"
+			parent   = "%[4]s"
+
+			depends_on = [sonarqube_qualityprofile.%[1]s_base]
+		}`, rnd, name, language, parent)
+}
+
+func TestAccSonarqubeQualityProfileParent(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileParentConfig(rnd, "testAccSonarqubeQualityProfileParent", "js", "testAccSonarqubeQualityProfileParent-base"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parent", "testAccSonarqubeQualityProfileParent-base"),
+					resource.TestCheckResourceAttr(name, "is_inherited", "true"),
+					resource.TestCheckResourceAttrSet(name, "active_rule_count"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileParentConfig(rnd, "testAccSonarqubeQualityProfileParent", "js", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parent", ""),
+					resource.TestCheckResourceAttr(name, "is_inherited", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileDefaultConfig(rnd string, name string, language string, isDefault bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name       = "%[2]s"
+			language   = "%[3]s"
+			is_default = %[4]t
+		}`, rnd, name, language, isDefault)
+}
+
+func TestAccSonarqubeQualityProfileDefault(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileDefaultConfig(rnd, "testAccSonarqubeQualityProfileDefault", "js", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "is_default", "true"),
+				),
+			},
+			{
+				// An unset is_default leaves the default quality profile unmanaged
+				Config:   testAccSonarqubeQualityProfileBasicConfig(rnd, "testAccSonarqubeQualityProfileDefault", "js"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccSonarqubeQualityProfileDefaultConfig(rnd, "testAccSonarqubeQualityProfileDefault", "js", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "is_default", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeQualityProfileCopyConfig(rnd string, name string, language string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s_source" {
+			name     = "testAccSonarqubeQualityProfileCopySource"
+			language = "%[3]s"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name      = "%[2]s"
+			language  = "%[3]s"
+			copy_from = sonarqube_qualityprofile.%[1]s_source.key
+		}`, rnd, name, language)
+}
+
+func TestAccSonarqubeQualityProfileCopyAndRename(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileCopyConfig(rnd, "testAccSonarqubeQualityProfileCopy", "js"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileCopy"),
+					resource.TestCheckResourceAttrSet(name, "key"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileCopyConfig(rnd, "testAccSonarqubeQualityProfileRenamed", "js"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfileRenamed"),
+					resource.TestCheckResourceAttrPair(name, "key", name, "id"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"copy_from"},
+			},
+		},
+	})
+}
+
+func TestAccSonarqubeQualityProfileCopyExisting(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Copying onto the name of the source must not overwrite it
+				Config:      testAccSonarqubeQualityProfileCopyConfig(rnd, "testAccSonarqubeQualityProfileCopySource", "js"),
+				ExpectError: regexp.MustCompile("already exists"),
+			},
+		},
+	})
+}
@@ -0,0 +1,348 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GetRules for unmarshalling response body of rules search
+type GetRules struct {
+	Total int64  `json:"total"`
+	P     int64  `json:"p"`
+	Ps    int64  `json:"ps"`
+	Rules []Rule `json:"rules"`
+}
+
+// GetRule for unmarshalling response body of rule show, create and update
+type GetRule struct {
+	Rule Rule `json:"rule"`
+}
+
+// Rule struct
+type Rule struct {
+	Key         string      `json:"key"`
+	Repo        string      `json:"repo"`
+	Name        string      `json:"name"`
+	MdDesc      string      `json:"mdDesc"`
+	HTMLDesc    string      `json:"htmlDesc"`
+	MdNote      string      `json:"mdNote"`
+	Severity    string      `json:"severity"`
+	Status      string      `json:"status"`
+	Type        string      `json:"type"`
+	Lang        string      `json:"lang"`
+	IsTemplate  bool        `json:"isTemplate"`
+	TemplateKey string      `json:"templateKey"`
+	Tags        []string    `json:"tags"`
+	SysTags     []string    `json:"sysTags"`
+	Params      []RuleParam `json:"params"`
+}
+
+// RuleParam used in Rule
+type RuleParam struct {
+	Key          string `json:"key"`
+	Desc         string `json:"htmlDesc"`
+	DefaultValue string `json:"defaultValue"`
+	Type         string `json:"type"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeRuleCreate,
+		Read:   resourceSonarqubeRuleRead,
+		Update: resourceSonarqubeRuleUpdate,
+		Delete: resourceSonarqubeRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeRuleImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"template_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the rule template the custom rule is created from",
+			},
+			"custom_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the custom rule, without the repository",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringLenBetween(1, 200),
+				),
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Key of the custom rule, including the repository",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Rule name",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringLenBetween(1, 200),
+				),
+			},
+			"markdown_description": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Rule description in markdown",
+			},
+			"severity": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Rule severity",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"}, false),
+				),
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Rule type",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"CODE_SMELL", "BUG", "VULNERABILITY", "SECURITY_HOTSPOT"}, false),
+				),
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "READY",
+				Description: "Rule status",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"BETA", "DEPRECATED", "READY"}, false),
+				),
+			},
+			"params": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Values of the template parameters",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeRuleCreate(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/rules/create"
+
+	rawQuery := url.Values{
+		"template_key":         []string{d.Get("template_key").(string)},
+		"custom_key":           []string{d.Get("custom_key").(string)},
+		"name":                 []string{d.Get("name").(string)},
+		"markdown_description": []string{d.Get("markdown_description").(string)},
+		"status":               []string{d.Get("status").(string)},
+		"params":               []string{expandRuleParams(d)},
+	}
+	if severity, ok := d.GetOk("severity"); ok {
+		rawQuery.Add("severity", severity.(string))
+	}
+	if ruleType, ok := d.GetOk("type"); ok {
+		rawQuery.Add("type", ruleType.(string))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeRuleCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("Error creating Sonarqube rule: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	ruleResponse := GetRule{}
+	err = json.NewDecoder(resp.Body).Decode(&ruleResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleCreate: Failed to decode json into struct: %+v", err)
+	}
+
+	d.SetId(ruleResponse.Rule.Key)
+	return resourceSonarqubeRuleRead(d, m)
+}
+
+func resourceSonarqubeRuleRead(d *schema.ResourceData, m interface{}) error {
+	return readRule(d, m, false)
+}
+
+// readRule reads the rule into the state. Only the configured params are read unless allParams is set,
+// the others keep the template defaults.
+func readRule(d *schema.ResourceData, m interface{}, allParams bool) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/rules/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"key": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeRuleRead",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			// Rule not found
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Sonarqube rule: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	ruleResponse := GetRule{}
+	err = json.NewDecoder(resp.Body).Decode(&ruleResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleRead: Failed to decode json into struct: %+v", err)
+	}
+
+	rule := ruleResponse.Rule
+	if rule.Status == "REMOVED" {
+		// Deleted custom rules are kept with status REMOVED
+		d.SetId("")
+		return nil
+	}
+
+	configuredParams := d.Get("params").(map[string]interface{})
+	params := make(map[string]interface{})
+	for _, param := range rule.Params {
+		if _, ok := configuredParams[param.Key]; ok || (allParams && param.DefaultValue != "") {
+			params[param.Key] = param.DefaultValue
+		}
+	}
+
+	d.Set("key", rule.Key)
+	d.Set("template_key", rule.TemplateKey)
+	d.Set("custom_key", strings.TrimPrefix(rule.Key, rule.Repo+":"))
+	d.Set("name", rule.Name)
+	d.Set("markdown_description", rule.MdDesc)
+	d.Set("severity", rule.Severity)
+	d.Set("type", rule.Type)
+	d.Set("status", rule.Status)
+	d.Set("params", params)
+
+	return nil
+}
+
+func resourceSonarqubeRuleUpdate(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/rules/update"
+
+	rawQuery := url.Values{
+		"key":                  []string{d.Id()},
+		"name":                 []string{d.Get("name").(string)},
+		"markdown_description": []string{d.Get("markdown_description").(string)},
+		"status":               []string{d.Get("status").(string)},
+		"params":               []string{expandRuleParams(d)},
+	}
+	if severity, ok := d.GetOk("severity"); ok {
+		rawQuery.Add("severity", severity.(string))
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeRuleUpdate",
+	)
+	if err != nil {
+		return fmt.Errorf("Error updating Sonarqube rule: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return resourceSonarqubeRuleRead(d, m)
+}
+
+func resourceSonarqubeRuleDelete(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/rules/delete"
+	sonarQubeURL.RawQuery = url.Values{
+		"key": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeRuleDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("Error deleting Sonarqube rule: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeRuleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// The configured params are unknown on import, so all params with a value are imported
+	if err := readRule(d, m, true); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// searchRules returns a page of rules matching the given api/rules/search parameters
+func searchRules(rawQuery url.Values, m interface{}) (GetRules, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/rules/search"
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"searchRules",
+	)
+	if err != nil {
+		return GetRules{}, fmt.Errorf("Error searching Sonarqube rules: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	rulesResponse := GetRules{}
+	err = json.NewDecoder(resp.Body).Decode(&rulesResponse)
+	if err != nil {
+		return GetRules{}, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return rulesResponse, nil
+}
+
+// expandRuleParams formats the params as semicolon separated list of key=value pairs.
+// The values are quoted, as regular expressions and XPath expressions often contain ; and =.
+// Quotes inside the values are doubled, like in CSV.
+func expandRuleParams(d *schema.ResourceData) string {
+	params := make([]string, 0)
+	for key, value := range d.Get("params").(map[string]interface{}) {
+		params = append(params, fmt.Sprintf("%s=\"%s\"", key, strings.ReplaceAll(value.(string), "\"", "\"\"")))
+	}
+	sort.Strings(params)
+	return strings.Join(params, ";")
+}
@@ -0,0 +1,185 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeRuleMetadata() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSonarqubeRuleMetadataCreate,
+		Read:   resourceSonarqubeRuleMetadataRead,
+		Update: resourceSonarqubeRuleMetadataUpdate,
+		Delete: resourceSonarqubeRuleMetadataDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeRuleMetadataImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"rule_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the rule, e.g. java:S1144",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags of the rule, in addition to the built-in tags",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"markdown_note": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Organisation note of the rule in markdown",
+			},
+			"original_tags": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Tags of the rule before they were managed, restored on destroy",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"original_markdown_note": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Note of the rule before it was managed, restored on destroy",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeRuleMetadataCreate(d *schema.ResourceData, m interface{}) error {
+	// Remember the current metadata to restore it on destroy
+	rule, err := getRule(d.Get("rule_key").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataCreate: %+v", err)
+	}
+	if rule == nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataCreate: Rule %s does not exist", d.Get("rule_key").(string))
+	}
+	d.Set("original_tags", rule.Tags)
+	d.Set("original_markdown_note", rule.MdNote)
+
+	err = updateRuleMetadata(d.Get("rule_key").(string), expandStringSet(d.Get("tags").(*schema.Set)), d.Get("markdown_note").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataCreate: %+v", err)
+	}
+
+	d.SetId(d.Get("rule_key").(string))
+	return resourceSonarqubeRuleMetadataRead(d, m)
+}
+
+func resourceSonarqubeRuleMetadataRead(d *schema.ResourceData, m interface{}) error {
+	rule, err := getRule(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataRead: %+v", err)
+	}
+	if rule == nil {
+		// Rule not found
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("rule_key", rule.Key)
+	d.Set("tags", rule.Tags)
+	d.Set("markdown_note", rule.MdNote)
+
+	return nil
+}
+
+func resourceSonarqubeRuleMetadataUpdate(d *schema.ResourceData, m interface{}) error {
+	err := updateRuleMetadata(d.Id(), expandStringSet(d.Get("tags").(*schema.Set)), d.Get("markdown_note").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeRuleMetadataRead(d, m)
+}
+
+func resourceSonarqubeRuleMetadataDelete(d *schema.ResourceData, m interface{}) error {
+	err := updateRuleMetadata(d.Id(), expandStringSet(d.Get("original_tags").(*schema.Set)), d.Get("original_markdown_note").(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeRuleMetadataDelete: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeRuleMetadataImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeRuleMetadataRead(d, m); err != nil {
+		return nil, err
+	}
+	// The metadata before terraform managed it is unknown, so destroy keeps the current metadata
+	d.Set("original_tags", d.Get("tags"))
+	d.Set("original_markdown_note", d.Get("markdown_note"))
+	return []*schema.ResourceData{d}, nil
+}
+
+// getRule returns the rule with the given key, or nil if it does not exist
+func getRule(key string, m interface{}) (*Rule, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/rules/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"key": []string{key},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getRule",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Error reading Sonarqube rule: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	ruleResponse := GetRule{}
+	err = json.NewDecoder(resp.Body).Decode(&ruleResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return &ruleResponse.Rule, nil
+}
+
+// updateRuleMetadata sets the tags and the note of a rule, leaving all other fields of the rule untouched
+func updateRuleMetadata(key string, tags []string, markdownNote string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/rules/update"
+	sonarQubeURL.RawQuery = url.Values{
+		"key":           []string{key},
+		"tags":          []string{strings.Join(tags, ",")},
+		"markdown_note": []string{markdownNote},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"updateRuleMetadata",
+	)
+	if err != nil {
+		return fmt.Errorf("Error updating Sonarqube rule: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_rule_metadata", &resource.Sweeper{
+		Name: "sonarqube_rule_metadata",
+		F:    testSweepSonarqubeRuleMetadataSweeper,
+	})
+}
+
+func testSweepSonarqubeRuleMetadataSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeRuleMetadataBasicConfig(rnd string, tags []string, note string) string {
+	formattedTags := generateHCLList(tags)
+	return fmt.Sprintf(`
+		resource "sonarqube_rule_metadata" "%[1]s" {
+			rule_key      = "javascript:S1116"
+			tags          = %[2]s
+			markdown_note = "%[3]s"
+		}`, rnd, formattedTags, note)
+}
+
+func TestAccSonarqubeRuleMetadataBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_rule_metadata." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeRuleMetadataBasicConfig(rnd, []string{"company-policy"}, "See our *style guide*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tags.#", "1"),
+					resource.TestCheckResourceAttr(name, "markdown_note", "See our *style guide*"),
+				),
+			},
+			{
+				Config: testAccSonarqubeRuleMetadataBasicConfig(rnd, []string{"company-policy", "team-a"}, "See our *updated* style guide"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tags.#", "2"),
+					resource.TestCheckResourceAttr(name, "markdown_note", "See our *updated* style guide"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("sonarqube_rule", &resource.Sweeper{
+		Name: "sonarqube_rule",
+		F:    testSweepSonarqubeRuleSweeper,
+	})
+}
+
+func testSweepSonarqubeRuleSweeper(r string) error {
+	return nil
+}
+
+func testAccSonarqubeRuleBasicConfig(rnd string, name string, severity string, expression string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_rule" "%[1]s" {
+			template_key         = "xml:XPathCheck"
+			custom_key           = "%[1]s"
+			name                 = "%[2]s"
+			markdown_description = "Do not use *deprecated* elements"
+			severity             = "%[3]s"
+			type                 = "CODE_SMELL"
+			params = {
+				expression = "%[4]s"
+				message    = "Deprecated element"
+			}
+		}`, rnd, name, severity, expression)
+}
+
+func TestAccSonarqubeRuleBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_rule." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeRuleBasicConfig(rnd, "testAccSonarqubeRule", "MAJOR", "//deprecated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", "xml:"+rnd),
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeRule"),
+					resource.TestCheckResourceAttr(name, "severity", "MAJOR"),
+					resource.TestCheckResourceAttr(name, "params.expression", "//deprecated"),
+				),
+			},
+			{
+				Config: testAccSonarqubeRuleBasicConfig(rnd, "testAccSonarqubeRuleUpdated", "CRITICAL", "//obsolete[@level='1']"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", "xml:"+rnd),
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeRuleUpdated"),
+					resource.TestCheckResourceAttr(name, "severity", "CRITICAL"),
+					resource.TestCheckResourceAttr(name, "params.expression", "//obsolete[@level='1']"),
+				),
+			},
+			{
+				// Double quotes in the values are escaped, the expression is passed escaped for HCL
+				Config: testAccSonarqubeRuleBasicConfig(rnd, "testAccSonarqubeRuleUpdated", "CRITICAL", `//obsolete[@level=\"1\"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "params.expression", `//obsolete[@level="1"]`),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -1,23 +1,31 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // User struct
 type User struct {
-	Login       string   `json:"login,omitempty"`
-	Name        string   `json:"name,omitempty"`
-	Email       string   `json:"email,omitempty"`
-	Permissions []string `json:"permissions,omitempty"`
-	IsActive    bool     `json:"active,omitempty"`
-	IsLocal     bool     `json:"local,omitempty"`
+	Login              string   `json:"login,omitempty"`
+	Name               string   `json:"name,omitempty"`
+	Email              string   `json:"email,omitempty"`
+	Permissions        []string `json:"permissions,omitempty"`
+	IsActive           bool     `json:"active,omitempty"`
+	IsLocal            bool     `json:"local,omitempty"`
+	ExternalIdentity   string   `json:"externalIdentity,omitempty"`
+	ExternalProvider   string   `json:"externalProvider,omitempty"`
+	Groups             []string `json:"groups,omitempty"`
+	ScmAccounts        []string `json:"scmAccounts,omitempty"`
+	LastConnectionDate string   `json:"lastConnectionDate,omitempty"`
+	TokensCount        int      `json:"tokensCount,omitempty"`
 }
 
 // GetUser for unmarshalling response body where users are retured
@@ -42,22 +50,29 @@ func resourceSonarqubeUser() *schema.Resource {
 			State: resourceSonarqubeUserImport,
 		},
 
+		CustomizeDiff: validateUserAnonymizeDiff,
+
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"login_name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"email": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"scm_accounts": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"password": {
 				Type:      schema.TypeString,
 				Optional:  true,
@@ -69,11 +84,43 @@ func resourceSonarqubeUser() *schema.Resource {
 				Default:  true,
 				ForceNew: true,
 			},
+			"external_identity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"external_provider"},
+			},
+			"external_provider": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"anonymize_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
 func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
+	// Deleted users are only deactivated. Creating a user with the same login reactivates it,
+	// but depending on the version it keeps its previous attributes.
+	deactivatedUsers, err := getUsers(url.Values{
+		"q":           []string{d.Get("login_name").(string)},
+		"deactivated": []string{"true"},
+	}, m)
+	if err != nil {
+		return fmt.Errorf("Error searching deactivated Sonarqube users: %+v", err)
+	}
+	reactivated := false
+	for _, user := range deactivatedUsers {
+		if user.Login == d.Get("login_name").(string) {
+			reactivated = true
+		}
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = "api/users/create"
 	isLocal := d.Get("is_local").(bool)
@@ -92,6 +139,12 @@ func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
 		rawQuery.Add("email", email.(string))
 	}
 
+	if scmAccounts, ok := d.GetOk("scm_accounts"); ok {
+		for _, scmAccount := range expandStringSet(scmAccounts.(*schema.Set)) {
+			rawQuery.Add("scmAccount", scmAccount)
+		}
+	}
+
 	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
@@ -119,50 +172,50 @@ func resourceSonarqubeUserCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("resourceSonarqubeUserCreate: Create response didn't contain the user login")
 	}
 
+	// Reconcile the attributes of a reactivated user with the configuration
+	if reactivated {
+		if err := updateUserAttributes(d, m); err != nil {
+			return fmt.Errorf("Error updating reactivated Sonarqube user: %+v", err)
+		}
+	}
+
+	// The identity provider can't be set on creation
+	if _, ok := d.GetOk("external_provider"); ok {
+		if err := updateUserIdentityProvider(d, m); err != nil {
+			return fmt.Errorf("Error updating Sonarqube user identity provider: %+v", err)
+		}
+	}
+
 	return resourceSonarqubeUserRead(d, m)
 }
 
 func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = "api/users/search"
-	sonarQubeURL.RawQuery = url.Values{
+	users, err := getUsers(url.Values{
 		"q": []string{d.Id()},
-	}.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"GET",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceSonarqubeUserRead",
-	)
+	}, m)
 	if err != nil {
 		return fmt.Errorf("Error reading Sonarqube user: %+v", err)
 	}
-	defer resp.Body.Close()
-
-	// Decode response into struct
-	userResponse := GetUser{}
-	err = json.NewDecoder(resp.Body).Decode(&userResponse)
-	if err != nil {
-		return fmt.Errorf("resourceSonarqubeUserCreate: Failed to decode json into struct: %+v", err)
-	}
 
 	// Loop over all users to see if the current user exists.
 	readSuccess := false
-	for _, value := range userResponse.Users {
-		if d.Id() == value.Login {
+	for _, value := range users {
+		// Deactivated users are treated as deleted
+		if d.Id() == value.Login && value.IsActive {
 			d.SetId(value.Login)
 			d.Set("login_name", value.Login)
 			d.Set("name", value.Name)
 			d.Set("email", value.Email)
+			d.Set("scm_accounts", value.ScmAccounts)
 			d.Set("is_local", value.IsLocal)
+			d.Set("external_identity", value.ExternalIdentity)
+			d.Set("external_provider", value.ExternalProvider)
 			readSuccess = true
 		}
 	}
 
 	if !readSuccess {
-		// user not found
+		// user not found or deactivated
 		d.SetId("")
 	}
 
@@ -171,26 +224,44 @@ func resourceSonarqubeUserRead(d *schema.ResourceData, m interface{}) error {
 
 func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
 
-	// handle default updates (api/users/update)
-	if d.HasChange("email") {
+	// handle login renames first, the other updates use the new login (api/users/update_login)
+	if d.HasChange("login_name") {
+		newLogin := d.Get("login_name").(string)
+
 		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-		sonarQubeURL.Path = "api/users/update"
+		sonarQubeURL.Path = "api/users/update_login"
 		sonarQubeURL.RawQuery = url.Values{
-			"login": []string{d.Id()},
-			"email": []string{d.Get("email").(string)},
+			"login":    []string{d.Id()},
+			"newLogin": []string{newLogin},
 		}.Encode()
 
 		resp, err := httpRequestHelper(
 			m.(*ProviderConfiguration).httpClient,
 			"POST",
 			sonarQubeURL.String(),
-			http.StatusOK,
+			http.StatusNoContent,
 			"resourceSonarqubeUserUpdate",
 		)
 		if err != nil {
-			return fmt.Errorf("Error updating Sonarqube user: %+v", err)
+			return fmt.Errorf("Error updating Sonarqube user login: %+v", err)
 		}
 		defer resp.Body.Close()
+
+		d.SetId(newLogin)
+	}
+
+	// handle default updates (api/users/update)
+	if d.HasChanges("name", "email", "scm_accounts") {
+		if err := updateUserAttributes(d, m); err != nil {
+			return fmt.Errorf("Error updating Sonarqube user: %+v", err)
+		}
+	}
+
+	// handle identity provider updates (api/users/update_identity_provider)
+	if d.HasChanges("external_identity", "external_provider") {
+		if err := updateUserIdentityProvider(d, m); err != nil {
+			return fmt.Errorf("Error updating Sonarqube user identity provider: %+v", err)
+		}
 	}
 
 	// handle password updates (api/users/change_password)
@@ -220,11 +291,39 @@ func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
+	anonymize := d.Get("anonymize_on_destroy").(bool)
+
+	if anonymize {
+		// A user deactivated since the last refresh can only be anonymized
+		activeUsers, err := getUsers(url.Values{
+			"q": []string{d.Id()},
+		}, m)
+		if err != nil {
+			return fmt.Errorf("Error deleting (deactivating) Sonarqube user: %+v", err)
+		}
+		isActive := false
+		for _, user := range activeUsers {
+			if user.Login == d.Id() {
+				isActive = true
+			}
+		}
+		if !isActive {
+			return userAnonymize(d.Id(), m)
+		}
+	}
+
+	rawQuery := url.Values{
+		"login": []string{d.Id()},
+	}
+
+	// Anonymizing wipes the personal data of the deactivated user
+	if anonymize {
+		rawQuery.Add("anonymize", "true")
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = "api/users/deactivate"
-	sonarQubeURL.RawQuery = url.Values{
-		"login": []string{d.Id()},
-	}.Encode()
+	sonarQubeURL.RawQuery = rawQuery.Encode()
 
 	resp, err := httpRequestHelper(
 		m.(*ProviderConfiguration).httpClient,
@@ -242,8 +341,168 @@ func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("anonymize_on_destroy", false)
 	if err := resourceSonarqubeUserRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// getUsers returns all users matching the given api/users/search parameters.
+// Without the deactivated parameter only active users are returned.
+func getUsers(rawQuery url.Values, m interface{}) ([]User, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/users/search"
+
+	rawQuery.Set("ps", "500")
+
+	users := make([]User, 0)
+	for page := int64(1); ; page++ {
+		rawQuery.Set("p", strconv.FormatInt(page, 10))
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		usersResponse, err := getUsersPage(sonarQubeURL, m)
+		if err != nil {
+			return nil, fmt.Errorf("getUsers: %+v", err)
+		}
+
+		users = append(users, usersResponse.Users...)
+
+		if len(usersResponse.Users) == 0 || page*usersResponse.Paging.PageSize >= usersResponse.Paging.Total {
+			break
+		}
+	}
+
+	return users, nil
+}
+
+// getUsersPage returns a page of the users matching the search parameters
+func getUsersPage(sonarQubeURL url.URL, m interface{}) (GetUser, error) {
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getUsersPage",
+	)
+	if err != nil {
+		return GetUser{}, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	usersResponse := GetUser{}
+	err = json.NewDecoder(resp.Body).Decode(&usersResponse)
+	if err != nil {
+		return GetUser{}, fmt.Errorf("Failed to decode json into struct: %+v", err)
+	}
+
+	return usersResponse, nil
+}
+
+// updateUserAttributes sets the name, email and scm accounts of the user to the configured values
+func updateUserAttributes(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/users/update"
+
+	rawQuery := url.Values{
+		"login": []string{d.Id()},
+		"name":  []string{d.Get("name").(string)},
+		"email": []string{d.Get("email").(string)},
+	}
+
+	// An empty scmAccount removes all scm accounts of the user
+	scmAccounts := expandStringSet(d.Get("scm_accounts").(*schema.Set))
+	if len(scmAccounts) == 0 {
+		scmAccounts = []string{""}
+	}
+	for _, scmAccount := range scmAccounts {
+		rawQuery.Add("scmAccount", scmAccount)
+	}
+
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"updateUserAttributes",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// updateUserIdentityProvider sets the external provider and identity of the user to the configured values
+func updateUserIdentityProvider(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/users/update_identity_provider"
+
+	rawQuery := url.Values{
+		"login":               []string{d.Id()},
+		"newExternalProvider": []string{d.Get("external_provider").(string)},
+	}
+
+	// Without an identity the login is used
+	if externalIdentity, ok := d.GetOk("external_identity"); ok {
+		rawQuery.Add("newExternalIdentity", externalIdentity.(string))
+	}
+
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"updateUserIdentityProvider",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// userAnonymize wipes the personal data of a deactivated user
+func userAnonymize(login string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/users/anonymize"
+	sonarQubeURL.RawQuery = url.Values{
+		"login": []string{login},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"userAnonymize",
+	)
+	if err != nil {
+		return fmt.Errorf("Error anonymizing Sonarqube user: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// validateUserAnonymizeDiff checks at plan time that the server can anonymize users, destroying the user would fail otherwise
+func validateUserAnonymizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("anonymize_on_destroy").(bool) {
+		return nil
+	}
+
+	installedVersion := m.(*ProviderConfiguration).sonarQubeVersion
+	minimumVersion, _ := version.NewVersion("9.7")
+	if installedVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("\"anonymize_on_destroy\" requires Sonarqube %s or later, the server runs %s", minimumVersion, installedVersion)
+	}
+
+	return nil
+}
@@ -2,9 +2,12 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -41,6 +44,8 @@ func TestAccSonarqubeUserLocal(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeUserLocal"),
 					resource.TestCheckResourceAttr(name, "email", "terraform-test@sonarqube.com"),
+					resource.TestCheckResourceAttr(name, "external_identity", "testAccSonarqubeUserLocal"),
+					resource.TestCheckResourceAttr(name, "external_provider", "sonarqube"),
 				),
 			},
 			{
@@ -99,3 +104,202 @@ func TestAccSonarqubeUserNotLocal(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeUserUpdateConfig(rnd string, login string, name string, scmAccounts []string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name   = "%[2]s"
+			name         = "%[3]s"
+			password     = "secret-sauce37!"
+			scm_accounts = %[4]s
+		}`, rnd, login, name, generateHCLList(scmAccounts))
+}
+
+func TestAccSonarqubeUserUpdate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserUpdateConfig(rnd, "testAccSonarqubeUserUpdate", "Test User", []string{"test-user"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeUserUpdate"),
+					resource.TestCheckResourceAttr(name, "name", "Test User"),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "scm_accounts.*", "test-user"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserUpdateConfig(rnd, "testAccSonarqubeUserRenamed", "Renamed User", []string{"test-user", "renamed-user"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeUserRenamed"),
+					resource.TestCheckResourceAttr(name, "login_name", "testAccSonarqubeUserRenamed"),
+					resource.TestCheckResourceAttr(name, "name", "Renamed User"),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "scm_accounts.*", "renamed-user"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserUpdateConfig(rnd, "testAccSonarqubeUserRenamed", "Renamed User", []string{}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccSonarqubeUserDeactivate deactivates the user outside of terraform and checks that it is found as deactivated user
+func testAccSonarqubeUserDeactivate(t *testing.T, login string) {
+	m := testAccProvider.Meta()
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = "api/users/deactivate"
+	sonarQubeURL.RawQuery = url.Values{
+		"login": []string{login},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"testAccSonarqubeUserDeactivate",
+	)
+	if err != nil {
+		t.Fatalf("failed to deactivate user %s: %+v", login, err)
+	}
+	resp.Body.Close()
+
+	users, err := getUsers(url.Values{
+		"q":           []string{login},
+		"deactivated": []string{"true"},
+	}, m)
+	if err != nil {
+		t.Fatalf("failed to search deactivated users: %+v", err)
+	}
+	for _, user := range users {
+		if user.Login == login {
+			return
+		}
+	}
+	t.Fatalf("user %s was not found as deactivated user", login)
+}
+
+func TestAccSonarqubeUserReactivate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserLocalConfig(rnd, "testAccSonarqubeUserReactivate", "terraform-test@sonarqube.com", "secret-sauce37!"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "email", "terraform-test@sonarqube.com"),
+				),
+			},
+			{
+				// The deactivated user is removed from the state and reactivated with the new configuration
+				PreConfig: func() { testAccSonarqubeUserDeactivate(t, "testAccSonarqubeUserReactivate") },
+				Config:    testAccSonarqubeUserUpdateConfig(rnd, "testAccSonarqubeUserReactivate", "Reactivated User", []string{"reactivated-user"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeUserReactivate"),
+					resource.TestCheckResourceAttr(name, "name", "Reactivated User"),
+					resource.TestCheckResourceAttr(name, "email", ""),
+					resource.TestCheckResourceAttr(name, "scm_accounts.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUserAnonymizeConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name           = "%[2]s"
+			name                 = "%[2]s"
+			email                = "%[2]s@sonarqube.com"
+			password             = "secret-sauce37!"
+			anonymize_on_destroy = true
+		}`, rnd, name)
+}
+
+// testAccCheckSonarqubeUserAnonymized checks that no user, active or deactivated, has the login anymore
+func testAccCheckSonarqubeUserAnonymized(login string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, deactivated := range []string{"false", "true"} {
+			users, err := getUsers(url.Values{
+				"q":           []string{login},
+				"deactivated": []string{deactivated},
+			}, testAccProvider.Meta())
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
+				if user.Login == login {
+					return fmt.Errorf("user %s was not anonymized", login)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccSonarqubeUserAnonymize(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSonarqubeUserAnonymized("testAccSonarqubeUserAnonymize"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserAnonymizeConfig(rnd, "testAccSonarqubeUserAnonymize"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "anonymize_on_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUserExternalIdentityConfig(rnd string, name string, externalIdentity string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name        = "%[2]s"
+			name              = "%[2]s"
+			is_local          = false
+			external_provider = "sonarqube"
+			external_identity = "%[3]s"
+		}`, rnd, name, externalIdentity)
+}
+
+func TestAccSonarqubeUserExternalIdentity(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserExternalIdentityConfig(rnd, "testAccSonarqubeUserExternalIdentity", "external-identity"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "external_provider", "sonarqube"),
+					resource.TestCheckResourceAttr(name, "external_identity", "external-identity"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserExternalIdentityConfig(rnd, "testAccSonarqubeUserExternalIdentity", "external-identity-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "external_identity", "external-identity-2"),
+				),
+			},
+		},
+	})
+}
@@ -1,13 +1,19 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // GetTokens struct
@@ -18,9 +24,23 @@ type GetTokens struct {
 
 // Token struct
 type Token struct {
-	Login string `json:"login,omitempty"`
-	Name  string `json:"name,omitempty"`
-	Token string `json:"token,omitempty"`
+	Login              string       `json:"login,omitempty"`
+	Name               string       `json:"name,omitempty"`
+	Token              string       `json:"token,omitempty"`
+	Type               string       `json:"type,omitempty"`
+	Project            TokenProject `json:"project,omitempty"`
+	CreatedAt          string       `json:"createdAt,omitempty"`
+	ExpirationDate     string       `json:"expirationDate,omitempty"`
+	LastConnectionDate string       `json:"lastConnectionDate,omitempty"`
+}
+
+// sonarqubeDateTimeFormat is the layout of the date times returned by the Sonarqube api
+const sonarqubeDateTimeFormat = "2006-01-02T15:04:05-0700"
+
+// TokenProject used in Token
+type TokenProject struct {
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
 }
 
 // Returns the resource represented by this file.
@@ -28,7 +48,16 @@ func resourceSonarqubeUserToken() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSonarqubeUserTokenCreate,
 		Read:   resourceSonarqubeUserTokenRead,
+		Update: resourceSonarqubeUserTokenUpdate,
 		Delete: resourceSonarqubeUserTokenDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeUserTokenImport,
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			validateUserTokenDiff,
+			rotateUserTokenDiff,
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -42,11 +71,62 @@ func resourceSonarqubeUserToken() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "USER_TOKEN",
+				ForceNew:    true,
+				Description: "Type of the token",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"USER_TOKEN", "GLOBAL_ANALYSIS_TOKEN", "PROJECT_ANALYSIS_TOKEN"}, false),
+				),
+			},
+			"project_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Key of the project a PROJECT_ANALYSIS_TOKEN is restricted to",
+			},
+			"expiration_date": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Date the token expires on, in the format YYYY-MM-DD",
+				ValidateDiagFunc: validation.ToDiagFunc(func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := time.Parse("2006-01-02", val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%q must be a date in the format YYYY-MM-DD: %+v", key, err))
+					}
+					return
+				}),
+			},
+			"rotation_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "Number of days after which the token is replaced by a new one",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
 			"token": {
 				Type:      schema.TypeString,
 				Computed:  true,
 				Sensitive: true,
 			},
+			"token_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the token in Sonarqube, rotated tokens get their creation time appended to the name",
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_connection_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -55,9 +135,28 @@ func resourceSonarqubeUserTokenCreate(d *schema.ResourceData, m interface{}) err
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = "api/user_tokens/generate"
 
+	// Rotated tokens need a unique name, as the new token is generated before the old one is revoked
+	tokenName := d.Get("name").(string)
+	if _, ok := d.GetOk("rotation_days"); ok {
+		tokenName = fmt.Sprintf("%s-%s", tokenName, time.Now().UTC().Format("20060102T150405"))
+	}
+
 	rawQuery := url.Values{
 		"login": []string{d.Get("login_name").(string)},
-		"name":  []string{d.Get("name").(string)},
+		"name":  []string{tokenName},
+	}
+
+	// Servers before 9.5 don't know the type parameter
+	if tokenType := d.Get("type").(string); tokenType != "USER_TOKEN" {
+		rawQuery.Add("type", tokenType)
+	}
+
+	if projectKey, ok := d.GetOk("project_key"); ok {
+		rawQuery.Add("projectKey", projectKey.(string))
+	}
+
+	if expirationDate, ok := d.GetOk("expiration_date"); ok {
+		rawQuery.Add("expirationDate", expirationDate.(string))
 	}
 
 	sonarQubeURL.RawQuery = rawQuery.Encode()
@@ -83,7 +182,8 @@ func resourceSonarqubeUserTokenCreate(d *schema.ResourceData, m interface{}) err
 
 	if tokenResponse.Login != "" {
 		// the ID consists of the login_name and the token name (foo/bar)
-		d.SetId(fmt.Sprintf("%s/%s", d.Get("login_name").(string), d.Get("name").(string)))
+		d.SetId(fmt.Sprintf("%s/%s", d.Get("login_name").(string), tokenName))
+		d.Set("token_name", tokenName)
 		// we set the token value here as the API wont return it later
 		if tokenResponse.Token != "" {
 			d.Set("token", tokenResponse.Token)
@@ -101,9 +201,12 @@ func resourceSonarqubeUserTokenRead(d *schema.ResourceData, m interface{}) error
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = "api/user_tokens/search"
 	// split d.Id into login_name and the token name (foo/bar)
-	login := strings.Split(d.Id(), "/")
+	login, tokenName, err := parseUserTokenID(d.Id())
+	if err != nil {
+		return err
+	}
 	sonarQubeURL.RawQuery = url.Values{
-		"login": []string{login[0]},
+		"login": []string{login},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -114,6 +217,11 @@ func resourceSonarqubeUserTokenRead(d *schema.ResourceData, m interface{}) error
 		"resourceSonarqubeUserTokenRead",
 	)
 	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			// The user and with it the token is gone
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error reading Sonarqube user tokens: %+v", err)
 	}
 	defer resp.Body.Close()
@@ -122,39 +230,52 @@ func resourceSonarqubeUserTokenRead(d *schema.ResourceData, m interface{}) error
 	getTokensResponse := GetTokens{}
 	err = json.NewDecoder(resp.Body).Decode(&getTokensResponse)
 	if err != nil {
-		return fmt.Errorf("resourceSonarqubeUserTokenCreate: Failed to decode json into struct: %+v", err)
+		return fmt.Errorf("resourceSonarqubeUserTokenRead: Failed to decode json into struct: %+v", err)
 	}
 
 	// Loop over all user token to see if the current token exists.
 	readSuccess := false
-	if getTokensResponse.Tokens != nil {
-		for _, value := range getTokensResponse.Tokens {
-			if d.Get("name").(string) == value.Name {
-				d.SetId(fmt.Sprintf("%s/%s", d.Get("login_name").(string), d.Get("name").(string)))
-				d.Set("login_name", getTokensResponse.Login)
-				d.Set("name", value.Name)
-				readSuccess = true
+	for _, value := range getTokensResponse.Tokens {
+		if tokenName == value.Name {
+			d.Set("login_name", getTokensResponse.Login)
+			d.Set("token_name", value.Name)
+			// Servers before 9.5 only have user tokens
+			if value.Type != "" {
+				d.Set("type", value.Type)
+			} else {
+				d.Set("type", "USER_TOKEN")
 			}
+			d.Set("project_key", value.Project.Key)
+			d.Set("created_at", value.CreatedAt)
+			d.Set("expires_at", userTokenExpiresAt(d, value))
+			d.Set("last_connection_date", value.LastConnectionDate)
+			readSuccess = true
 		}
-	} else {
-		// the user has no tokens
-		d.SetId("")
 	}
 
 	if !readSuccess {
-		// Token not found
+		// Token not found, e.g. because it was revoked. Removing it from the state generates a new token.
 		d.SetId("")
 	}
 
 	return nil
 }
 
+func resourceSonarqubeUserTokenUpdate(d *schema.ResourceData, m interface{}) error {
+	// Only rotation_days can change in place, it is applied by the next plan
+	return resourceSonarqubeUserTokenRead(d, m)
+}
+
 func resourceSonarqubeUserTokenDelete(d *schema.ResourceData, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = "api/user_tokens/revoke"
+	login, tokenName, err := parseUserTokenID(d.Id())
+	if err != nil {
+		return err
+	}
 	sonarQubeURL.RawQuery = url.Values{
-		"login": []string{d.Get("login_name").(string)},
-		"name":  []string{d.Get("name").(string)},
+		"login": []string{login},
+		"name":  []string{tokenName},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -171,3 +292,119 @@ func resourceSonarqubeUserTokenDelete(d *schema.ResourceData, m interface{}) err
 
 	return nil
 }
+
+func validateUserTokenDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	installedVersion := m.(*ProviderConfiguration).sonarQubeVersion
+
+	expirationVersion, _ := version.NewVersion("9.6")
+	if _, ok := d.GetOk("expiration_date"); ok && installedVersion.LessThan(expirationVersion) {
+		return fmt.Errorf("\"expiration_date\" requires Sonarqube %s or later, the server runs %s", expirationVersion, installedVersion)
+	}
+
+	// The type and project may only be known after other resources are applied
+	if !d.NewValueKnown("type") || !d.NewValueKnown("project_key") {
+		return nil
+	}
+
+	tokenType := d.Get("type").(string)
+	typeVersion, _ := version.NewVersion("9.5")
+	if tokenType != "USER_TOKEN" && installedVersion.LessThan(typeVersion) {
+		return fmt.Errorf("tokens of type %s require Sonarqube %s or later, the server runs %s", tokenType, typeVersion, installedVersion)
+	}
+
+	_, hasProject := d.GetOk("project_key")
+	if tokenType == "PROJECT_ANALYSIS_TOKEN" && !hasProject {
+		return fmt.Errorf("\"project_key\" is required for tokens of type PROJECT_ANALYSIS_TOKEN")
+	}
+	if tokenType != "PROJECT_ANALYSIS_TOKEN" && hasProject {
+		return fmt.Errorf("\"project_key\" is only supported for tokens of type PROJECT_ANALYSIS_TOKEN")
+	}
+
+	return nil
+}
+
+func resourceSonarqubeUserTokenImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	_, tokenName, err := parseUserTokenID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubeUserTokenRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeUserTokenImport: User token %q not found", id)
+	}
+
+	// The token value can't be read, it stays empty. Rotated tokens are adopted under the name without
+	// the creation time, rotation_days is left to the configuration.
+	d.Set("name", rotatedUserTokenSuffix.ReplaceAllString(tokenName, ""))
+	if expiresAt, err := time.Parse(sonarqubeDateTimeFormat, d.Get("expires_at").(string)); err == nil {
+		d.Set("expiration_date", expiresAt.Format("2006-01-02"))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// rotatedUserTokenSuffix matches the creation time appended to the names of rotated tokens
+var rotatedUserTokenSuffix = regexp.MustCompile(`-\d{8}T\d{6}$`)
+
+// rotateUserTokenDiff replaces tokens which are older than rotation_days
+func rotateUserTokenDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// expires_at depends on the rotation
+	if d.Id() != "" && d.HasChange("rotation_days") {
+		if err := d.SetNewComputed("expires_at"); err != nil {
+			return err
+		}
+	}
+
+	rotationDays, ok := d.GetOk("rotation_days")
+	if !ok || d.Id() == "" {
+		return nil
+	}
+
+	createdAt, err := time.Parse(sonarqubeDateTimeFormat, d.Get("created_at").(string))
+	if err != nil {
+		// The creation time is unknown until the token is read
+		return nil
+	}
+
+	if time.Now().Before(createdAt.AddDate(0, 0, rotationDays.(int))) {
+		return nil
+	}
+
+	if err := d.SetNewComputed("token_name"); err != nil {
+		return err
+	}
+	return d.ForceNew("token_name")
+}
+
+// parseUserTokenID splits the ID of a user token into the login and the token name
+func parseUserTokenID(id string) (string, string, error) {
+	// Logins can't contain a slash, token names can
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid user token ID %q, expected login/name", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// userTokenExpiresAt returns the time the token expires or is rotated, whichever is earlier
+func userTokenExpiresAt(d *schema.ResourceData, token Token) string {
+	rotationDays, ok := d.GetOk("rotation_days")
+	if !ok {
+		return token.ExpirationDate
+	}
+
+	createdAt, err := time.Parse(sonarqubeDateTimeFormat, token.CreatedAt)
+	if err != nil {
+		return token.ExpirationDate
+	}
+	rotatesAt := createdAt.AddDate(0, 0, rotationDays.(int))
+
+	if expiresAt, err := time.Parse(sonarqubeDateTimeFormat, token.ExpirationDate); err == nil && expiresAt.Before(rotatesAt) {
+		return token.ExpirationDate
+	}
+	return rotatesAt.Format(sonarqubeDateTimeFormat)
+}
@@ -1,10 +1,16 @@
 package sonarqube
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -45,6 +51,196 @@ func TestAccSonarqubeUserTokenBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeUserToken"),
 				),
 			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateId:           "testAccSonarqubeUserToken/testAccSonarqubeUserToken",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUserTokenProjectConfig(rnd string, name string, expirationDate string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+		resource "sonarqube_user_token" "%[1]s" {
+			login_name      = "admin"
+			name            = "%[2]s"
+			type            = "PROJECT_ANALYSIS_TOKEN"
+			project_key     = sonarqube_project.%[1]s.project
+			expiration_date = "%[3]s"
+		}`, rnd, name, expirationDate)
+}
+
+func TestAccSonarqubeUserTokenProject(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user_token." + rnd
+	expirationDate := time.Now().AddDate(0, 0, 30).Format("2006-01-02")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserTokenProjectConfig(rnd, "testAccSonarqubeUserTokenProject", expirationDate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "type", "PROJECT_ANALYSIS_TOKEN"),
+					resource.TestCheckResourceAttr(name, "project_key", "testAccSonarqubeUserTokenProject"),
+					resource.TestCheckResourceAttr(name, "expiration_date", expirationDate),
+					resource.TestCheckResourceAttrSet(name, "created_at"),
+					resource.TestCheckResourceAttrSet(name, "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSonarqubeUserTokenRotationConfig(rnd string, name string, rotationDays int) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[2]s"
+			name       = "%[2]s"
+			password   = "secret-sauce37!"
+		}
+		resource "sonarqube_user_token" "%[1]s" {
+			login_name    = sonarqube_user.%[1]s.login_name
+			name          = "%[2]s"
+			rotation_days = %[3]d
+
+			lifecycle {
+				create_before_destroy = true
+			}
+		}`, rnd, name, rotationDays)
+}
+
+func TestAccSonarqubeUserTokenRotation(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user_token." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserTokenRotationConfig(rnd, "testAccSonarqubeUserTokenRotation", 90),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeUserTokenRotation"),
+					resource.TestMatchResourceAttr(name, "token_name", regexp.MustCompile(`^testAccSonarqubeUserTokenRotation-\d{8}T\d{6}$`)),
+					resource.TestCheckResourceAttrSet(name, "expires_at"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserTokenRotationConfig(rnd, "testAccSonarqubeUserTokenRotation", 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rotation_days", "30"),
+					resource.TestCheckResourceAttrSet(name, "expires_at"),
+				),
+			},
+			{
+				// Rotated tokens are imported by their token_name and adopted under their name
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) { return s.RootModule().Resources[name].Primary.ID, nil },
+				ImportStateVerify: true,
+				// The token value can't be read, the rotation is left to the configuration
+				ImportStateVerifyIgnore: []string{"token", "rotation_days", "expires_at"},
+			},
 		},
 	})
 }
+
+// testUserTokenRotationDiff returns the diff of a token with rotation_days = 30 which was created at the given time
+func testUserTokenRotationDiff(t *testing.T, createdAt time.Time) *terraform.InstanceDiff {
+	state := &terraform.InstanceState{
+		ID: "ci-user/ci",
+		Attributes: map[string]string{
+			"id":            "ci-user/ci",
+			"name":          "ci",
+			"login_name":    "ci-user",
+			"type":          "USER_TOKEN",
+			"rotation_days": "30",
+			"token":         "secret",
+			"token_name":    "ci",
+			"created_at":    createdAt.Format(sonarqubeDateTimeFormat),
+			"expires_at":    createdAt.AddDate(0, 0, 30).Format(sonarqubeDateTimeFormat),
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":          "ci",
+		"login_name":    "ci-user",
+		"rotation_days": 30,
+	})
+	meta := &ProviderConfiguration{
+		sonarQubeVersion: version.Must(version.NewVersion("9.9")),
+	}
+
+	diff, err := resourceSonarqubeUserToken().Diff(context.Background(), state, config, meta)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return diff
+}
+
+func TestRotateUserTokenDiffStale(t *testing.T) {
+	diff := testUserTokenRotationDiff(t, time.Now().AddDate(0, 0, -31))
+
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected a stale token to be replaced, got diff %#v", diff)
+	}
+	tokenName, ok := diff.Attributes["token_name"]
+	if !ok || !tokenName.NewComputed || !tokenName.RequiresNew {
+		t.Fatalf("expected a new token_name, got %#v", tokenName)
+	}
+}
+
+func TestRotateUserTokenDiffFresh(t *testing.T) {
+	diff := testUserTokenRotationDiff(t, time.Now().AddDate(0, 0, -1))
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no changes for a fresh token, got diff %#v", diff)
+	}
+}
+
+func TestUserTokenExpiresAt(t *testing.T) {
+	createdAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	rotatesAt := createdAt.AddDate(0, 0, 30).Format(sonarqubeDateTimeFormat)
+	expiresEarly := createdAt.AddDate(0, 0, 10).Format(sonarqubeDateTimeFormat)
+	expiresLate := createdAt.AddDate(0, 0, 60).Format(sonarqubeDateTimeFormat)
+
+	cases := []struct {
+		name           string
+		rotationDays   int
+		expirationDate string
+		expected       string
+	}{
+		{"without rotation", 0, expiresLate, expiresLate},
+		{"without expiration", 30, "", rotatesAt},
+		{"rotated before expiration", 30, expiresLate, rotatesAt},
+		{"expires before rotation", 30, expiresEarly, expiresEarly},
+	}
+
+	for _, c := range cases {
+		raw := map[string]interface{}{
+			"name":       "ci",
+			"login_name": "ci-user",
+		}
+		if c.rotationDays > 0 {
+			raw["rotation_days"] = c.rotationDays
+		}
+		d := schema.TestResourceDataRaw(t, resourceSonarqubeUserToken().Schema, raw)
+		token := Token{
+			CreatedAt:      createdAt.Format(sonarqubeDateTimeFormat),
+			ExpirationDate: c.expirationDate,
+		}
+
+		if actual := userTokenExpiresAt(d, token); actual != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, actual)
+		}
+	}
+}